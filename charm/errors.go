@@ -5,9 +5,15 @@ import (
 	"fmt"
 )
 
+// ErrInterrupted is returned by prompts when the user cancels them with ctrl+c
+var ErrInterrupted = errors.New("interrupted")
+
 func RenderError(message string, err ...error) error {
 	formattedMessage := ErrorStyle.Render(message)
 	if len(err) > 0 && err[0] != nil {
+		if message == "" {
+			return fmt.Errorf("%w", err[0])
+		}
 		return fmt.Errorf("%s: %w", formattedMessage, err[0])
 	}
 	return errors.New(formattedMessage)
@@ -8,32 +8,16 @@ import (
 	"github.com/charmbracelet/lipgloss"
 )
 
+// styles are set from the active theme, see SetTheme
 var (
-	SuccessStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#00FF00"))
-
-	ErrorStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FF0000")). // Red color
-			Bold(true)
-
-	WarningStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFF00")). // Yellow color
-			Bold(true)
-
-	inputStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
-	titleStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("63"))
-
-	JsonStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("205")).
-			Padding(1).
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("62"))
-
-	PlainTextStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("244")).
-			Padding(1).
-			Border(lipgloss.NormalBorder()).
-			BorderForeground(lipgloss.Color("240"))
+	SuccessStyle   lipgloss.Style
+	ErrorStyle     lipgloss.Style
+	WarningStyle   lipgloss.Style
+	JsonStyle      lipgloss.Style
+	PlainTextStyle lipgloss.Style
+
+	inputStyle lipgloss.Style
+	titleStyle lipgloss.Style
 )
 
 // Function to create and run the bubbletea model
@@ -51,6 +35,9 @@ func GetSensitiveInput(placeholder string, defaultValue string) (string, error)
 	if err != nil {
 		return "", err
 	}
+	if finalModel.(model).cancelled {
+		return "", ErrInterrupted
+	}
 
 	return finalModel.(model).textInput.Value(), nil
 }
@@ -68,6 +55,9 @@ func GetTextInput(placeholder string, defaultValue string) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	if finalModel.(model).cancelled {
+		return "", ErrInterrupted
+	}
 
 	return finalModel.(model).textInput.Value(), nil
 }
@@ -76,6 +66,7 @@ func GetTextInput(placeholder string, defaultValue string) (string, error) {
 type model struct {
 	textInput textinput.Model
 	err       error
+	cancelled bool
 }
 
 func (m model) Init() tea.Cmd {
@@ -93,6 +84,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, tea.Quit
 		case tea.KeyCtrlC:
+			m.cancelled = true
 			return m, tea.Quit
 		}
 	}
@@ -16,7 +16,7 @@ import (
 func RenderProjectTable(projects []openapi_chaos_client.ProjectResponse) string {
 	headerStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("#FAFAFA")).
+		Foreground(activeTheme.Color(ColorHeader)).
 		Padding(0, 1)
 
 	cellStyle := lipgloss.NewStyle().
@@ -58,6 +58,7 @@ func RenderProjectTable(projects []openapi_chaos_client.ProjectResponse) string
 	s := table.DefaultStyles()
 	s.Header = headerStyle
 	s.Cell = cellStyle
+	s.Selected = s.Selected.Foreground(activeTheme.Color(ColorAccent))
 	t.SetStyles(s)
 
 	return t.View()
@@ -66,7 +67,7 @@ func RenderProjectTable(projects []openapi_chaos_client.ProjectResponse) string
 func RenderOrgTable(orgs []openapi_chaos_client.OrganisationResponse) string {
 	headerStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("#FAFAFA")).
+		Foreground(activeTheme.Color(ColorHeader)).
 		Padding(0, 1)
 
 	cellStyle := lipgloss.NewStyle().
@@ -108,6 +109,7 @@ func RenderOrgTable(orgs []openapi_chaos_client.OrganisationResponse) string {
 	s := table.DefaultStyles()
 	s.Header = headerStyle
 	s.Cell = cellStyle
+	s.Selected = s.Selected.Foreground(activeTheme.Color(ColorAccent))
 	t.SetStyles(s)
 
 	return t.View()
@@ -116,7 +118,7 @@ func RenderOrgTable(orgs []openapi_chaos_client.OrganisationResponse) string {
 func RenderSecretsTable(secrets []openapi_chaos_client.SecretMetaResponse) string {
 	headerStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("#FAFAFA")).
+		Foreground(activeTheme.Color(ColorHeader)).
 		Padding(0, 1)
 
 	cellStyle := lipgloss.NewStyle().
@@ -185,6 +187,7 @@ func RenderSecretsTable(secrets []openapi_chaos_client.SecretMetaResponse) strin
 	s := table.DefaultStyles()
 	s.Header = headerStyle
 	s.Cell = cellStyle
+	s.Selected = s.Selected.Foreground(activeTheme.Color(ColorAccent))
 	t.SetStyles(s)
 
 	return t.View()
@@ -193,7 +196,7 @@ func RenderSecretsTable(secrets []openapi_chaos_client.SecretMetaResponse) strin
 func RenderFuncTable(functions []openapi_chaos_client.Function) string {
 	headerStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("#FAFAFA")).
+		Foreground(activeTheme.Color(ColorHeader)).
 		Padding(0, 1)
 
 	cellStyle := lipgloss.NewStyle().
@@ -230,6 +233,7 @@ func RenderFuncTable(functions []openapi_chaos_client.Function) string {
 	s := table.DefaultStyles()
 	s.Header = headerStyle
 	s.Cell = cellStyle
+	s.Selected = s.Selected.Foreground(activeTheme.Color(ColorAccent))
 	t.SetStyles(s)
 
 	return t.View()
@@ -238,7 +242,7 @@ func RenderFuncTable(functions []openapi_chaos_client.Function) string {
 func RenderDNSTable(records map[string]string) string {
 	headerStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("#FAFAFA")).
+		Foreground(activeTheme.Color(ColorHeader)).
 		Padding(0, 2)
 	cellStyle := lipgloss.NewStyle().
 		Padding(0, 2)
@@ -267,6 +271,7 @@ func RenderDNSTable(records map[string]string) string {
 	s := table.DefaultStyles()
 	s.Header = headerStyle
 	s.Cell = cellStyle
+	s.Selected = s.Selected.Foreground(activeTheme.Color(ColorAccent))
 	t.SetStyles(s)
 
 	return t.View()
@@ -275,7 +280,7 @@ func RenderDNSTable(records map[string]string) string {
 func RenderHostTable(hosts []openapi_chaos_client.Host) string {
 	headerStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("#FAFAFA")).
+		Foreground(activeTheme.Color(ColorHeader)).
 		Padding(0, 2)
 	cellStyle := lipgloss.NewStyle().
 		Padding(0, 2)
@@ -324,6 +329,7 @@ func RenderHostTable(hosts []openapi_chaos_client.Host) string {
 	s := table.DefaultStyles()
 	s.Header = headerStyle
 	s.Cell = cellStyle
+	s.Selected = s.Selected.Foreground(activeTheme.Color(ColorAccent))
 	t.SetStyles(s)
 
 	return t.View()
@@ -332,7 +338,7 @@ func RenderHostTable(hosts []openapi_chaos_client.Host) string {
 func RenderProviderTable(providers []openapi_chaos_client.Provider) string {
 	headerStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("#FAFAFA")).
+		Foreground(activeTheme.Color(ColorHeader)).
 		Padding(0, 0)
 
 	cellStyle := lipgloss.NewStyle().
@@ -348,7 +354,7 @@ func RenderProviderTable(providers []openapi_chaos_client.Provider) string {
 	var rows []table.Row
 	for _, provider := range providers {
 		row := table.Row{
-			lipgloss.NewStyle().Foreground(lipgloss.Color("#FF69B4")).Render(provider.Name),
+			lipgloss.NewStyle().Foreground(activeTheme.Color(ColorAccent)).Render(provider.Name),
 			strings.Join(provider.Locations.Countries, ", "),
 			strings.Join(provider.Locations.Cities, ", "),
 			strings.Join(provider.Locations.Continents, ", "),
@@ -366,7 +372,73 @@ func RenderProviderTable(providers []openapi_chaos_client.Provider) string {
 	s := table.DefaultStyles()
 	s.Header = headerStyle
 	s.Cell = cellStyle
+	s.Selected = s.Selected.Foreground(activeTheme.Color(ColorAccent))
 	t.SetStyles(s)
 
 	return t.View()
 }
+
+// RenderSummaryTable renders the outcome of a batch operation, one row per resource
+func RenderSummaryTable(columns []string, rows [][]string) string {
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(activeTheme.Color(ColorHeader)).
+		Padding(0, 1)
+
+	cellStyle := lipgloss.NewStyle().
+		Padding(0, 1)
+
+	// size columns to their widest value
+	tableColumns := make([]table.Column, len(columns))
+	for i, title := range columns {
+		width := len(title)
+		for _, row := range rows {
+			if i < len(row) && len(row[i]) > width {
+				width = len(row[i])
+			}
+		}
+		tableColumns[i] = table.Column{Title: title, Width: width}
+	}
+
+	var tableRows []table.Row
+	for _, row := range rows {
+		tableRows = append(tableRows, table.Row(row))
+	}
+
+	t := table.New(
+		table.WithColumns(tableColumns),
+		table.WithRows(tableRows),
+		table.WithHeight(len(rows)+1),
+	)
+
+	s := table.DefaultStyles()
+	s.Header = headerStyle
+	s.Cell = cellStyle
+	s.Selected = lipgloss.NewStyle()
+	t.SetStyles(s)
+
+	return t.View()
+}
+
+// RenderDiff colours the added and removed lines of a unified diff
+func RenderDiff(diff string) string {
+	headerStyle := lipgloss.NewStyle().Bold(true)
+	hunkStyle := lipgloss.NewStyle().Foreground(activeTheme.Color(ColorTitle))
+	addedStyle := lipgloss.NewStyle().Foreground(activeTheme.Color(ColorSuccess))
+	removedStyle := lipgloss.NewStyle().Foreground(activeTheme.Color(ColorError))
+
+	lines := strings.Split(strings.TrimSuffix(diff, "\n"), "\n")
+	for i, line := range lines {
+		switch {
+		case i < 2 && (strings.HasPrefix(line, "+++") || strings.HasPrefix(line, "---")):
+			lines[i] = headerStyle.Render(line)
+		case strings.HasPrefix(line, "@@"):
+			lines[i] = hunkStyle.Render(line)
+		case strings.HasPrefix(line, "+"):
+			lines[i] = addedStyle.Render(line)
+		case strings.HasPrefix(line, "-"):
+			lines[i] = removedStyle.Render(line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,222 @@
+package charm
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Prompt asks for a line of text, validate is called on enter and its error is shown until the
+// input is valid. An empty input is replaced with defaultValue.
+func Prompt(title string, defaultValue string, validate func(string) error) (string, error) {
+	input := textinput.New()
+	input.Placeholder = defaultValue
+	input.Focus()
+
+	p := tea.NewProgram(promptModel{title: title, defaultValue: defaultValue, validate: validate, input: input})
+	finalModel, err := p.Run()
+	if err != nil {
+		return "", err
+	}
+	m := finalModel.(promptModel)
+	if m.cancelled {
+		return "", ErrInterrupted
+	}
+	return m.value(), nil
+}
+
+type promptModel struct {
+	title        string
+	defaultValue string
+	validate     func(string) error
+	input        textinput.Model
+	err          error
+	cancelled    bool
+	done         bool
+}
+
+func (m promptModel) value() string {
+	if value := strings.TrimSpace(m.input.Value()); value != "" {
+		return value
+	}
+	return m.defaultValue
+}
+
+func (m promptModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m promptModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.Type {
+		case tea.KeyEnter:
+			m.err = nil
+			if m.validate != nil {
+				m.err = m.validate(m.value())
+			}
+			if m.err == nil {
+				m.done = true
+				return m, tea.Quit
+			}
+			return m, nil
+		case tea.KeyCtrlC:
+			m.cancelled = true
+			return m, tea.Quit
+		}
+	}
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m promptModel) View() string {
+	if m.done {
+		return fmt.Sprintf("%s %s\n", titleStyle.Render(m.title), m.value())
+	}
+	view := fmt.Sprintf("%s\n%s\n", titleStyle.Render(m.title), inputStyle.Render(m.input.View()))
+	if m.err != nil {
+		view += ErrorStyle.Render(m.err.Error()) + "\n"
+	}
+	return view
+}
+
+// Select asks to pick one of options, or any number of them when multi is set, and returns the
+// indexes picked. Options in selected are picked initially.
+func Select(title string, options []string, multi bool, selected ...int) ([]int, error) {
+	if len(options) == 0 {
+		return nil, fmt.Errorf("nothing to choose from for %s", strings.TrimSuffix(title, ":"))
+	}
+
+	m := selectModel{title: title, options: options, multi: multi, picked: map[int]bool{}}
+	for _, i := range selected {
+		m.picked[i] = true
+	}
+
+	p := tea.NewProgram(m)
+	finalModel, err := p.Run()
+	if err != nil {
+		return nil, err
+	}
+	m = finalModel.(selectModel)
+	if m.cancelled {
+		return nil, ErrInterrupted
+	}
+	return m.result(), nil
+}
+
+type selectModel struct {
+	title     string
+	options   []string
+	multi     bool
+	cursor    int
+	picked    map[int]bool
+	cancelled bool
+	done      bool
+}
+
+func (m selectModel) result() []int {
+	if !m.multi {
+		return []int{m.cursor}
+	}
+	var indexes []int
+	for i := range m.options {
+		if m.picked[i] {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+func (m selectModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m selectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "ctrl+c":
+			m.cancelled = true
+			return m, tea.Quit
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.options)-1 {
+				m.cursor++
+			}
+		case " ", "x":
+			if m.multi {
+				m.picked[m.cursor] = !m.picked[m.cursor]
+			}
+		case "enter":
+			m.done = true
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+func (m selectModel) View() string {
+	if m.done {
+		var picked []string
+		for _, i := range m.result() {
+			picked = append(picked, m.options[i])
+		}
+		return fmt.Sprintf("%s %s\n", titleStyle.Render(m.title), strings.Join(picked, ", "))
+	}
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(m.title))
+	b.WriteString("\n")
+	for i, option := range m.options {
+		cursor := "  "
+		if i == m.cursor {
+			cursor = inputStyle.Render("> ")
+		}
+		box := ""
+		if m.multi {
+			box = "[ ] "
+			if m.picked[i] {
+				box = "[x] "
+			}
+		}
+		b.WriteString(cursor + box + option + "\n")
+	}
+	help := "↑/↓ to move, enter to choose"
+	if m.multi {
+		help = "↑/↓ to move, space to pick, enter to confirm"
+	}
+	b.WriteString(watchHeaderStyle.Render(help))
+	b.WriteString("\n")
+	return b.String()
+}
+
+// Confirm asks a yes or no question, an empty answer is defaultValue
+func Confirm(title string, defaultValue bool) (bool, error) {
+	hint := "y/N"
+	if defaultValue {
+		hint = "Y/n"
+	}
+
+	answer, err := Prompt(fmt.Sprintf("%s [%s]", title, hint), "", func(value string) error {
+		switch strings.ToLower(value) {
+		case "", "y", "yes", "n", "no":
+			return nil
+		}
+		return fmt.Errorf("answer y or n")
+	})
+	if err != nil {
+		return false, err
+	}
+
+	switch strings.ToLower(answer) {
+	case "y", "yes":
+		return true, nil
+	case "n", "no":
+		return false, nil
+	}
+	return defaultValue, nil
+}
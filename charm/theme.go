@@ -0,0 +1,193 @@
+package charm
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Colour roles a palette assigns colours to
+const (
+	ColorHeader         = "header"          // table headers
+	ColorText           = "text"            // plain text output
+	ColorMuted          = "muted"           // secondary text, e.g. the watch status line
+	ColorBorder         = "border"          // borders around plain text output
+	ColorAccent         = "accent"          // highlighted values, e.g. provider names
+	ColorHighlight      = "highlight"       // rows that changed while watching
+	ColorSuccess        = "success"         // success messages
+	ColorError          = "error"           // error messages
+	ColorWarning        = "warning"         // warnings
+	ColorInput          = "input"           // prompts and json output
+	ColorTitle          = "title"           // prompt titles and json borders
+	ColorChartPrimary   = "chart_primary"   // first data set of a graph
+	ColorChartSecondary = "chart_secondary" // second data set of a graph
+)
+
+// Palette maps colour roles to hex (#RRGGBB) or ANSI (0-255) colours
+type Palette map[string]string
+
+// ThemeAuto picks the dark or light palette from the terminal background
+const ThemeAuto = "auto"
+
+// ThemeCustom uses the auto theme as a base for a user supplied palette
+const ThemeCustom = "custom"
+
+var palettes = map[string]Palette{
+	"dark": {
+		ColorHeader:         "#FAFAFA",
+		ColorText:           "244",
+		ColorMuted:          "244",
+		ColorBorder:         "240",
+		ColorAccent:         "#FF69B4",
+		ColorHighlight:      "#FFFF00",
+		ColorSuccess:        "#00FF00",
+		ColorError:          "#FF0000",
+		ColorWarning:        "#FFFF00",
+		ColorInput:          "205",
+		ColorTitle:          "63",
+		ColorChartPrimary:   "10",
+		ColorChartSecondary: "21",
+	},
+	"light": {
+		ColorHeader:         "#1A1A1A",
+		ColorText:           "238",
+		ColorMuted:          "242",
+		ColorBorder:         "246",
+		ColorAccent:         "#C71585",
+		ColorHighlight:      "#0000AF",
+		ColorSuccess:        "#008700",
+		ColorError:          "#D70000",
+		ColorWarning:        "#AF5F00",
+		ColorInput:          "161",
+		ColorTitle:          "57",
+		ColorChartPrimary:   "28",
+		ColorChartSecondary: "19",
+	},
+	"high-contrast": {
+		ColorHeader:         "15",
+		ColorText:           "15",
+		ColorMuted:          "15",
+		ColorBorder:         "15",
+		ColorAccent:         "13",
+		ColorHighlight:      "11",
+		ColorSuccess:        "10",
+		ColorError:          "9",
+		ColorWarning:        "11",
+		ColorInput:          "14",
+		ColorTitle:          "11",
+		ColorChartPrimary:   "10",
+		ColorChartSecondary: "14",
+	},
+}
+
+var colorPattern = regexp.MustCompile(`^(#[0-9a-fA-F]{6}|#[0-9a-fA-F]{3}|[0-9]{1,3})$`)
+
+// Theme holds the colour used for every role
+type Theme struct {
+	Name   string
+	colors map[string]lipgloss.TerminalColor
+}
+
+// Color returns the colour of a role, unknown roles use the terminal's default colour
+func (t Theme) Color(role string) lipgloss.TerminalColor {
+	if c, ok := t.colors[role]; ok {
+		return c
+	}
+	return lipgloss.NoColor{}
+}
+
+// ThemeNames returns the names accepted by LoadTheme
+func ThemeNames() []string {
+	names := []string{ThemeAuto, ThemeCustom}
+	for name := range palettes {
+		names = append(names, name)
+	}
+	sort.Strings(names[2:])
+	return names
+}
+
+// LoadTheme builds the named theme, overriding its colours with any set in custom.
+// An empty name is the same as auto, which follows the terminal background.
+func LoadTheme(name string, custom Palette) (Theme, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		name = ThemeAuto
+	}
+
+	theme := Theme{Name: name, colors: map[string]lipgloss.TerminalColor{}}
+	switch name {
+	case ThemeAuto, ThemeCustom:
+		// adaptive colours are resolved against the terminal background when first rendered
+		for role, dark := range palettes["dark"] {
+			theme.colors[role] = lipgloss.AdaptiveColor{Light: palettes["light"][role], Dark: dark}
+		}
+	default:
+		palette, ok := palettes[name]
+		if !ok {
+			return Theme{}, fmt.Errorf("unknown theme %q, expected one of %s", name, strings.Join(ThemeNames(), ", "))
+		}
+		for role, color := range palette {
+			theme.colors[role] = lipgloss.Color(color)
+		}
+	}
+
+	for role, color := range custom {
+		if _, ok := palettes["dark"][role]; !ok {
+			return Theme{}, fmt.Errorf("unknown palette colour %q", role)
+		}
+		if !colorPattern.MatchString(color) {
+			return Theme{}, fmt.Errorf("invalid colour %q for %s, expected #RRGGBB or an ANSI colour number", color, role)
+		}
+		theme.colors[role] = lipgloss.Color(color)
+	}
+
+	return theme, nil
+}
+
+var activeTheme, _ = LoadTheme("dark", nil)
+
+func init() {
+	SetTheme(activeTheme)
+}
+
+// CurrentTheme returns the theme styles are rendered with
+func CurrentTheme() Theme {
+	return activeTheme
+}
+
+// SetTheme switches every style in the package to the given theme
+func SetTheme(theme Theme) {
+	activeTheme = theme
+
+	SuccessStyle = lipgloss.NewStyle().
+		Foreground(theme.Color(ColorSuccess))
+
+	ErrorStyle = lipgloss.NewStyle().
+		Foreground(theme.Color(ColorError)).
+		Bold(true)
+
+	WarningStyle = lipgloss.NewStyle().
+		Foreground(theme.Color(ColorWarning)).
+		Bold(true)
+
+	inputStyle = lipgloss.NewStyle().Foreground(theme.Color(ColorInput))
+	titleStyle = lipgloss.NewStyle().Bold(true).Foreground(theme.Color(ColorTitle))
+
+	JsonStyle = lipgloss.NewStyle().
+		Foreground(theme.Color(ColorInput)).
+		Padding(1).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Color(ColorTitle))
+
+	PlainTextStyle = lipgloss.NewStyle().
+		Foreground(theme.Color(ColorText)).
+		Padding(1).
+		Border(lipgloss.NormalBorder()).
+		BorderForeground(theme.Color(ColorBorder))
+
+	ChangedStyle = lipgloss.NewStyle().Bold(true).Foreground(theme.Color(ColorHighlight))
+	watchHeaderStyle = lipgloss.NewStyle().Foreground(theme.Color(ColorMuted))
+}
@@ -0,0 +1,35 @@
+package charm
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadTheme(t *testing.T) {
+	theme, err := LoadTheme("Light", nil)
+	require.NoError(t, err)
+	assert.Equal(t, lipgloss.Color("#1A1A1A"), theme.Color(ColorHeader))
+
+	theme, err = LoadTheme("", nil)
+	require.NoError(t, err)
+	assert.Equal(t, lipgloss.AdaptiveColor{Light: "#1A1A1A", Dark: "#FAFAFA"}, theme.Color(ColorHeader))
+
+	theme, err = LoadTheme(ThemeCustom, Palette{ColorHeader: "#123456", ColorAccent: "208"})
+	require.NoError(t, err)
+	assert.Equal(t, lipgloss.Color("#123456"), theme.Color(ColorHeader))
+	assert.Equal(t, lipgloss.Color("208"), theme.Color(ColorAccent))
+}
+
+func TestLoadThemeErrors(t *testing.T) {
+	_, err := LoadTheme("sepia", nil)
+	assert.ErrorContains(t, err, "unknown theme")
+
+	_, err = LoadTheme("dark", Palette{"background": "#000000"})
+	assert.ErrorContains(t, err, "unknown palette colour")
+
+	_, err = LoadTheme("dark", Palette{ColorHeader: "white"})
+	assert.ErrorContains(t, err, "invalid colour")
+}
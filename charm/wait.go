@@ -0,0 +1,154 @@
+package charm
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/term"
+)
+
+// PollFunc checks a condition once, it reports done when the condition holds and a status
+// describing the progress so far
+type PollFunc func(ctx context.Context) (done bool, status string, err error)
+
+// WaitFor calls poll every interval until it is done, fails or ctx ends. On a terminal a spinner
+// shows the latest status on stderr, otherwise every new status is printed to stderr unless quiet
+// is set. It returns the last status.
+func WaitFor(ctx context.Context, interval time.Duration, quiet bool, poll PollFunc) (string, error) {
+	if quiet || !term.IsTerminal(os.Stderr.Fd()) {
+		return waitPlain(ctx, interval, quiet, poll)
+	}
+
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	s.Style = watchHeaderStyle
+
+	// stops the goroutine below once the program has finished
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	p := tea.NewProgram(waitModel{ctx: ctx, interval: interval, poll: poll, spinner: s, status: "starting..."},
+		tea.WithOutput(os.Stderr))
+	go func() {
+		<-ctx.Done()
+		p.Send(waitDoneMsg{err: ctx.Err()})
+	}()
+
+	model, err := p.Run()
+	if err != nil {
+		return "", err
+	}
+	m := model.(waitModel)
+	return m.status, m.err
+}
+
+// waitPlain polls without redrawing, used when stderr isn't a terminal
+func waitPlain(ctx context.Context, interval time.Duration, quiet bool, poll PollFunc) (string, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	last := ""
+	for {
+		done, status, err := poll(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return last, ctx.Err()
+			}
+			return last, err
+		}
+		if status != last && !quiet {
+			fmt.Fprintln(os.Stderr, status)
+		}
+		last = status
+		if done {
+			return last, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return last, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+type waitPollMsg struct {
+	done   bool
+	status string
+	err    error
+}
+
+type waitTickMsg struct{}
+
+type waitDoneMsg struct {
+	err error
+}
+
+type waitModel struct {
+	ctx      context.Context
+	interval time.Duration
+	poll     PollFunc
+	spinner  spinner.Model
+
+	status string
+	done   bool
+	err    error
+}
+
+func (m waitModel) check() tea.Msg {
+	done, status, err := m.poll(m.ctx)
+	return waitPollMsg{done: done, status: status, err: err}
+}
+
+func (m waitModel) Init() tea.Cmd {
+	return tea.Batch(m.spinner.Tick, m.check)
+}
+
+func (m waitModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			m.err = ErrInterrupted
+			return m, tea.Quit
+		}
+	case waitDoneMsg:
+		if m.err == nil && !m.done {
+			m.err = msg.err
+		}
+		return m, tea.Quit
+	case waitPollMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			if m.ctx.Err() != nil {
+				m.err = m.ctx.Err()
+			}
+			return m, tea.Quit
+		}
+		m.status = msg.status
+		if msg.done {
+			m.done = true
+			return m, tea.Quit
+		}
+		return m, tea.Tick(m.interval, func(time.Time) tea.Msg {
+			return waitTickMsg{}
+		})
+	case waitTickMsg:
+		return m, m.check
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+func (m waitModel) View() string {
+	if m.done || m.err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%s %s\n", m.spinner.View(), m.status)
+}
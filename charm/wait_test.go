@@ -0,0 +1,40 @@
+package charm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWaitFor(t *testing.T) {
+	polls := 0
+	status, err := WaitFor(context.Background(), time.Millisecond, true, func(ctx context.Context) (bool, string, error) {
+		polls++
+		return polls == 3, fmt.Sprintf("poll %d", polls), nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "poll 3", status)
+}
+
+func TestWaitForTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	status, err := WaitFor(ctx, time.Millisecond, true, func(ctx context.Context) (bool, string, error) {
+		return false, "starting", nil
+	})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, "starting", status, "the last status explains what was being waited for")
+}
+
+func TestWaitForError(t *testing.T) {
+	failure := errors.New("not found")
+	_, err := WaitFor(context.Background(), time.Millisecond, true, func(ctx context.Context) (bool, string, error) {
+		return false, "", failure
+	})
+	assert.ErrorIs(t, err, failure)
+}
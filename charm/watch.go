@@ -0,0 +1,164 @@
+package charm
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/charmbracelet/x/term"
+)
+
+var (
+	// ChangedStyle highlights lines that changed since the previous poll
+	ChangedStyle lipgloss.Style
+
+	watchHeaderStyle lipgloss.Style
+)
+
+// WatchFunc renders a single frame of a watched command
+type WatchFunc func() (string, error)
+
+// Watch re-runs render every interval and redraws its output in place until ctrl+c is pressed,
+// lines that changed since the previous frame are highlighted. When stdout isn't a terminal
+// every frame is printed in turn instead.
+func Watch(interval time.Duration, render WatchFunc) error {
+	if !term.IsTerminal(os.Stdout.Fd()) {
+		return watchPlain(interval, render)
+	}
+
+	p := tea.NewProgram(watchModel{interval: interval, render: render})
+	_, err := p.Run()
+	return err
+}
+
+// watchPlain prints every frame sequentially, used when the output is piped
+func watchPlain(interval time.Duration, render WatchFunc) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		frame, err := render()
+		fmt.Println(watchHeaderStyle.Render(fmt.Sprintf("Every %s, updated %s", interval, time.Now().Format(time.TimeOnly))))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+		} else {
+			fmt.Println(frame)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+type watchFrameMsg struct {
+	frame string
+	err   error
+}
+
+type watchTickMsg struct{}
+
+type watchModel struct {
+	interval time.Duration
+	render   WatchFunc
+
+	frame    string
+	previous map[string]bool
+	changed  map[int]bool
+	err      error
+	updated  time.Time
+}
+
+func (m watchModel) fetch() tea.Msg {
+	frame, err := m.render()
+	return watchFrameMsg{frame: frame, err: err}
+}
+
+func (m watchModel) Init() tea.Cmd {
+	return m.fetch
+}
+
+func (m watchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "q", "esc":
+			return m, tea.Quit
+		}
+	case watchFrameMsg:
+		m.updated = time.Now()
+		m.err = msg.err
+		if msg.err == nil {
+			m.changed = changedLines(m.previous, msg.frame)
+			m.previous = frameLines(msg.frame)
+			m.frame = msg.frame
+		}
+		return m, tea.Tick(m.interval, func(time.Time) tea.Msg {
+			return watchTickMsg{}
+		})
+	case watchTickMsg:
+		return m, m.fetch
+	}
+	return m, nil
+}
+
+func (m watchModel) View() string {
+	var b strings.Builder
+
+	status := "loading..."
+	if !m.updated.IsZero() {
+		status = fmt.Sprintf("updated %s", m.updated.Format(time.TimeOnly))
+	}
+	b.WriteString(watchHeaderStyle.Render(fmt.Sprintf("Every %s, %s (ctrl+c to quit)", m.interval, status)))
+	b.WriteString("\n")
+
+	for i, line := range strings.Split(strings.TrimRight(m.frame, "\n"), "\n") {
+		if m.changed[i] {
+			line = ChangedStyle.Render(ansi.Strip(line))
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+
+	if m.err != nil {
+		b.WriteString(ErrorStyle.Render(m.err.Error()))
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// frameLines indexes the lines of a frame by their unstyled content
+func frameLines(frame string) map[string]bool {
+	lines := map[string]bool{}
+	for _, line := range strings.Split(frame, "\n") {
+		lines[ansi.Strip(line)] = true
+	}
+	return lines
+}
+
+// changedLines returns the line numbers of frame that weren't present in the previous frame,
+// nothing is highlighted on the first frame
+func changedLines(previous map[string]bool, frame string) map[int]bool {
+	changed := map[int]bool{}
+	if previous == nil {
+		return changed
+	}
+	for i, line := range strings.Split(strings.TrimRight(frame, "\n"), "\n") {
+		if !previous[ansi.Strip(line)] {
+			changed[i] = true
+		}
+	}
+	return changed
+}
@@ -0,0 +1,18 @@
+package charm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChangedLines(t *testing.T) {
+	assert.Empty(t, changedLines(nil, "a\nb\n"), "nothing is highlighted on the first frame")
+
+	previous := frameLines("NAME  STATUS\napi   pending\n")
+	changed := changedLines(previous, "NAME  STATUS\napi   running\nweb   pending\n")
+	assert.Equal(t, map[int]bool{1: true, 2: true}, changed)
+
+	styled := frameLines(ErrorStyle.Render("api") + "\n")
+	assert.Empty(t, changedLines(styled, "api\n"), "styling changes aren't highlighted")
+}
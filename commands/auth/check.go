@@ -6,6 +6,7 @@ import (
 
 	"github.com/qernal/cli-qernal/charm"
 	"github.com/qernal/cli-qernal/pkg/client"
+	"github.com/qernal/cli-qernal/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -33,7 +34,7 @@ var checkCmd = &cobra.Command{
 		ctx := context.Background()
 		_, err = client.New(ctx, nil, nil, tokenToUse)
 		if err != nil {
-			return charm.RenderError("❌ invalid token, auth check failed with", err)
+			return charm.RenderError("❌ invalid token, auth check failed with", utils.NewError(utils.ExitAuth, "", err))
 		}
 
 		// TODO: Display last token refresh
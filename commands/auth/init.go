@@ -11,6 +11,7 @@ import (
 
 	"github.com/qernal/cli-qernal/charm"
 	"github.com/qernal/cli-qernal/pkg/client"
+	"github.com/qernal/cli-qernal/pkg/utils"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"gopkg.in/yaml.v2"
@@ -18,6 +19,10 @@ import (
 
 type Qernalconfig struct {
 	Token string `yaml:"token"`
+	// Theme is one of auto, dark, light, high-contrast or custom
+	Theme string `yaml:"theme,omitempty"`
+	// Palette overrides colours of the theme by role, e.g. header: "#FAFAFA"
+	Palette map[string]string `yaml:"palette,omitempty"`
 }
 
 var (
@@ -39,10 +44,10 @@ var (
 			}
 			// allow user to overwrite existing token
 			if len(token) > 0 {
-				fmt.Println(charm.WarningStyle.Render("Found an auth token, entering a new one will cause an overwrite"))
+				fmt.Fprintln(os.Stderr, charm.WarningStyle.Render("Found an auth token, entering a new one will cause an overwrite"))
 				token, err = charm.GetSensitiveInput("Enter your token", "")
 				if err != nil {
-					fmt.Println(charm.ErrorStyle.Render(fmt.Sprintf("error retrieving input %s", err.Error())))
+					fmt.Fprintln(os.Stderr, charm.ErrorStyle.Render(fmt.Sprintf("error retrieving input %s", err.Error())))
 					return err
 				}
 
@@ -50,7 +55,7 @@ var (
 
 			err = ValidateToken(token)
 			if err != nil {
-				return charm.RenderError("token validation failed:", err)
+				return charm.RenderError("token validation failed:", utils.NewError(utils.ExitAuth, "", err))
 			}
 
 			return saveConfig(token)
@@ -64,7 +69,7 @@ func GetQernalToken() (string, error) {
 	// 1. Check environment variable
 	if token := os.Getenv("QERNAL_TOKEN"); token != "" {
 		if verbose {
-			fmt.Println(charm.SuccessStyle.Render("configuring CLI using environment variable ✅"))
+			fmt.Fprintln(os.Stderr, charm.SuccessStyle.Render("configuring CLI using environment variable ✅"))
 		}
 		return token, nil
 	}
@@ -72,27 +77,39 @@ func GetQernalToken() (string, error) {
 	// 2. Check config file
 	if config, err := readConfig(cfgPath); err == nil {
 		if err := validatePermissions(cfgPath); err != nil {
-			fmt.Println(charm.WarningStyle.Render(err.Error())) // Use custom style
+			fmt.Fprintln(os.Stderr, charm.WarningStyle.Render(err.Error())) // Use custom style
 		}
 		return config.Token, nil
 	} else if os.IsNotExist(err) {
 		// File doesn't exist, continue to prompt user
 		token, err := charm.GetSensitiveInput("clientid@clientsecret", "")
 		if err != nil {
-			fmt.Println(charm.ErrorStyle.Render(fmt.Sprintf("error retrieving input %s", err.Error())))
-			return "", err
+			return "", tokenInputError(err)
 		}
 		return token, nil
 
 	}
 	token, err := charm.GetSensitiveInput("Enter your token", "")
 	if err != nil {
-		fmt.Println(charm.ErrorStyle.Render(fmt.Sprintf("error retrieving input %s", err.Error())))
-		return "", err
+		return "", tokenInputError(err)
 	}
 	return token, nil
 }
 
+// tokenInputError classifies a failed token prompt, a cancelled prompt keeps its own exit code
+func tokenInputError(err error) error {
+	fmt.Fprintln(os.Stderr, charm.ErrorStyle.Render(fmt.Sprintf("error retrieving input %s", err.Error())))
+	if errors.Is(err, charm.ErrInterrupted) {
+		return err
+	}
+	return utils.NewError(utils.ExitAuth, "no qernal token available", err)
+}
+
+// ReadConfig reads $HOME/.qernal/config.yaml
+func ReadConfig() (Qernalconfig, error) {
+	return readConfig(cfgPath)
+}
+
 func readConfig(cfgPath string) (Qernalconfig, error) {
 	viper.SetConfigFile(cfgPath)
 
@@ -110,9 +127,16 @@ func readConfig(cfgPath string) (Qernalconfig, error) {
 	return cfg, nil
 }
 
-// TODO: use viper to only save update values
+// saveConfig writes the token to the config file, other settings in the file are kept
 func saveConfig(token string) error {
-	cfg := &Qernalconfig{Token: token}
+	cfg := map[string]interface{}{}
+	if existing, err := os.ReadFile(cfgPath); err == nil {
+		if err := yaml.Unmarshal(existing, &cfg); err != nil {
+			return fmt.Errorf("unable to parse existing config file %s, %w", cfgPath, err)
+		}
+	}
+	cfg["token"] = token
+
 	data, err := yaml.Marshal(cfg)
 	if err != nil {
 		return err
@@ -0,0 +1,234 @@
+package functions
+
+import (
+	"context"
+	"slices"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/qernal/cli-qernal/charm"
+	"github.com/qernal/cli-qernal/commands/auth"
+	"github.com/qernal/cli-qernal/pkg/client"
+	"github.com/qernal/cli-qernal/pkg/common"
+	"github.com/qernal/cli-qernal/pkg/helpers"
+	"github.com/qernal/cli-qernal/pkg/utils"
+	openapi_chaos_client "github.com/qernal/openapi-chaos-go-client"
+	"github.com/spf13/cobra"
+)
+
+// outcome of applying a single function definition
+const (
+	actionCreated   = "created"
+	actionUpdated   = "updated"
+	actionUnchanged = "unchanged"
+	actionFailed    = "failed"
+)
+
+// functionResult is the outcome of a batch operation on one function
+type functionResult struct {
+	Name   string `json:"name"`
+	Source string `json:"source,omitempty"`
+	Action string `json:"action"`
+	ID     string `json:"id,omitempty"`
+	// Duration is how long the operation took, rounded to milliseconds
+	Duration string `json:"duration,omitempty"`
+	Error    string `json:"error,omitempty"`
+	// Rollback is how the change was undone after an --atomic deploy failed
+	Rollback string `json:"rollback,omitempty"`
+
+	// revision is the function's revision after the operation and prior the function before it was updated
+	revision string
+	prior    *openapi_chaos_client.Function
+}
+
+// fail records err as the reason the operation failed
+func (r *functionResult) fail(err error) {
+	r.Action = actionFailed
+	r.Error = ansi.Strip(err.Error())
+}
+
+func NewApplyCmd(printer *utils.Printer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Create or update functions from a definition file",
+		Long: `Create or update every function in a definition file. Functions are matched to the
+functions already in their project by name, missing functions are created and functions whose
+definition changed are updated. Functions that match their definition are left alone.
+
+Every function is attempted even if others fail, unless --fail-fast is set. With --atomic the first
+failure undoes the whole deploy: functions that were created are deleted and functions that were
+updated are restored to their previous spec. A summary of the result and duration of every function
+is printed at the end.`,
+		Example: `  qernal functions apply -f functions.yaml
+
+  # Deploy up to 8 functions at a time
+  qernal functions apply -f functions/ --parallel 8
+
+  # Deploy the same definitions to another project
+  qernal functions apply -f functions.yaml --project prod`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			token, err := auth.GetQernalToken()
+			if err != nil {
+				return charm.RenderError("error creating qernal client", err)
+			}
+
+			qc, err := client.New(ctx, nil, nil, token)
+			if err != nil {
+				return charm.RenderError("error creating qernal client", err)
+			}
+
+			parallel, failFast, err := batchOptions(cmd)
+			if err != nil {
+				return err
+			}
+
+			documents, err := loadDefinitions(cmd)
+			if err != nil {
+				return err
+			}
+			if err := resolveProjects(cmd, &qc, documents); err != nil {
+				return err
+			}
+			if err := resolveSecrets(ctx, cmd, &qc, printer, documents); err != nil {
+				return err
+			}
+
+			liveFunctions, err := liveFunctionsByName(ctx, &qc, printer, documents)
+			if err != nil {
+				return err
+			}
+			if helpers.DryRun(cmd) {
+				return helpers.PrintDryRun(printer, applyRequests(documents, liveFunctions)...)
+			}
+
+			atomic, _ := cmd.Flags().GetBool("atomic")
+			results := runBatch(ctx, documents, parallel, failFast || atomic, func(ctx context.Context, doc helpers.FunctionDocument) functionResult {
+				return applyFunction(ctx, &qc, printer, doc, liveFunctions[doc.Body.ProjectId])
+			})
+
+			if atomic && batchError("apply", results) != nil {
+				rollbackFailures := rollbackResults(ctx, &qc, printer, results)
+				printResults(printer, results)
+				return atomicError("apply", results, rollbackFailures)
+			}
+
+			printResults(printer, results)
+
+			return batchError("apply", results)
+		},
+	}
+
+	addDefinitionFlags(cmd)
+	addSecretCheckFlag(cmd)
+	helpers.AddDryRunFlag(cmd)
+	addBatchFlags(cmd)
+	addAtomicFlag(cmd)
+	_ = cmd.MarkFlagRequired("file")
+	utils.AddQuietFlag(cmd)
+
+	return cmd
+}
+
+// liveFunctionsByName lists the functions of every project referenced by the definitions, keyed by project then name
+func liveFunctionsByName(ctx context.Context, qc *client.QernalAPIClient, printer *utils.Printer, documents []helpers.FunctionDocument) (map[string]map[string]openapi_chaos_client.Function, error) {
+	projects := map[string]map[string]openapi_chaos_client.Function{}
+	for _, doc := range documents {
+		projects[doc.Body.ProjectId] = nil
+	}
+
+	for projectID := range projects {
+		existing, err := helpers.PaginateFunctions(printer, ctx, qc, 0, projectID)
+		if err != nil {
+			return nil, err
+		}
+
+		byName := map[string]openapi_chaos_client.Function{}
+		for _, function := range existing {
+			byName[function.Name] = function
+		}
+		projects[projectID] = byName
+	}
+
+	return projects, nil
+}
+
+// applyRequests are the requests apply sends to make the live functions match documents
+func applyRequests(documents []helpers.FunctionDocument, live map[string]map[string]openapi_chaos_client.Function) []helpers.DryRunRequest {
+	var requests []helpers.DryRunRequest
+	for _, doc := range documents {
+		existing, ok := live[doc.Body.ProjectId][doc.Body.Name]
+		switch {
+		case !ok:
+			requests = append(requests, helpers.CreateFunctionRequest(doc.Body))
+		case helpers.FunctionChanged(existing, doc.Body):
+			requests = append(requests, helpers.UpdateFunctionRequest(existing.Id, existing.Revision, doc.Body))
+		}
+	}
+	return requests
+}
+
+// applyFunction creates function if it doesn't exist in live, or updates it if its definition changed
+func applyFunction(ctx context.Context, qc *client.QernalAPIClient, printer *utils.Printer, doc helpers.FunctionDocument, live map[string]openapi_chaos_client.Function) functionResult {
+	function := doc.Body
+	result := functionResult{Name: function.Name, Source: doc.Position()}
+
+	existing, ok := live[function.Name]
+	switch {
+	case !ok:
+		qFunc, err := helpers.CreateFunction(ctx, qc, printer, function)
+		if err != nil {
+			result.fail(err)
+			return result
+		}
+		result.Action, result.ID, result.revision = actionCreated, qFunc.Id, qFunc.Revision
+	case helpers.FunctionChanged(existing, function):
+		qFunc, err := helpers.UpdateFunction(ctx, qc, printer, existing.Id, existing.Revision, function)
+		if err != nil {
+			result.ID = existing.Id
+			result.fail(err)
+			return result
+		}
+		result.Action, result.ID, result.revision = actionUpdated, qFunc.Id, qFunc.Revision
+		result.prior = &existing
+	default:
+		result.Action, result.ID = actionUnchanged, existing.Id
+	}
+
+	return result
+}
+
+// printResults prints the outcome of a batch operation as IDs, json or a summary table
+func printResults(printer *utils.Printer, results []functionResult) {
+	if common.Quiet {
+		for _, result := range results {
+			if result.ID != "" && result.Action != actionFailed {
+				printer.PrintIDs(result.ID)
+			}
+		}
+		return
+	}
+
+	if common.OutputFormat == "json" {
+		printer.PrintResource(utils.FormatOutput(results, common.OutputFormat))
+		return
+	}
+
+	columns := []string{"Name", "Result", "ID", "Duration", "Source", "Error"}
+	rolledBack := slices.ContainsFunc(results, func(result functionResult) bool {
+		return result.Rollback != ""
+	})
+	if rolledBack {
+		columns = append(columns, "Rollback")
+	}
+
+	var rows [][]string
+	for _, result := range results {
+		row := []string{result.Name, result.Action, result.ID, result.Duration, result.Source, result.Error}
+		if rolledBack {
+			row = append(row, result.Rollback)
+		}
+		rows = append(rows, row)
+	}
+	printer.PrintResource(charm.RenderSummaryTable(columns, rows))
+}
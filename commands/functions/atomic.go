@@ -0,0 +1,80 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/qernal/cli-qernal/pkg/client"
+	"github.com/qernal/cli-qernal/pkg/helpers"
+	"github.com/qernal/cli-qernal/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// outcome of rolling back a function after an --atomic deploy failed
+const (
+	rollbackDeleted  = "deleted"
+	rollbackRestored = "restored"
+	rollbackFailed   = "failed"
+)
+
+// addAtomicFlag adds --atomic to a command deploying several functions
+func addAtomicFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool("atomic", false, "undo every change if any function fails, created functions are deleted and updated ones restored, implies --fail-fast")
+}
+
+// rollbackResults undoes the changes of every created or updated function in results. Created
+// functions are deleted and updated ones are restored to the spec they had before. It returns the
+// number of functions that couldn't be rolled back.
+func rollbackResults(ctx context.Context, qc *client.QernalAPIClient, printer *utils.Printer, results []functionResult) int {
+	failed := 0
+	for i := range results {
+		result := &results[i]
+		var err error
+		switch result.Action {
+		case actionCreated:
+			result.Rollback = rollbackDeleted
+			_, httpRes, deleteErr := qc.FunctionsAPI.FunctionsDelete(ctx, result.ID).Execute()
+			if deleteErr != nil {
+				err = helpers.FunctionRequestError(printer, fmt.Sprintf("unable to delete function %s", result.Name), httpRes, deleteErr)
+			}
+		case actionUpdated:
+			result.Rollback = fmt.Sprintf("%s revision %s", rollbackRestored, result.prior.Revision)
+			_, err = helpers.UpdateFunction(ctx, qc, printer, result.ID, result.revision, helpers.FunctionToBody(*result.prior))
+		default:
+			continue
+		}
+
+		if err != nil {
+			printer.Logger.Debug("unable to roll back function",
+				slog.String("function", result.Name),
+				slog.String("error", err.Error()))
+			result.Rollback = fmt.Sprintf("%s: %s", rollbackFailed, ansi.Strip(err.Error()))
+			failed++
+		}
+	}
+	return failed
+}
+
+// atomicError summarises an --atomic deploy, nothing is left changed unless a rollback failed
+func atomicError(action string, results []functionResult, rollbackFailures int) error {
+	failed, rolledBack := 0, 0
+	for _, result := range results {
+		switch {
+		case result.Action == actionFailed:
+			failed++
+		case result.Rollback != "":
+			rolledBack++
+		}
+	}
+	if failed == 0 {
+		return nil
+	}
+
+	message := fmt.Sprintf("%s failed for %d of %d functions, rolled back %d", action, failed, len(results), rolledBack-rollbackFailures)
+	if rollbackFailures > 0 {
+		return utils.NewError(utils.ExitPartial, fmt.Sprintf("%s, %d could not be rolled back", message, rollbackFailures))
+	}
+	return utils.NewError(utils.ExitError, message)
+}
@@ -0,0 +1,100 @@
+package functions
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/qernal/cli-qernal/pkg/client"
+	"github.com/qernal/cli-qernal/pkg/utils"
+	openapi_chaos_client "github.com/qernal/openapi-chaos-go-client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func atomicFunction(id string, revision string, image string) openapi_chaos_client.Function {
+	return openapi_chaos_client.Function{
+		Id:          id,
+		ProjectId:   "b4b3c1d0-3b1b-4e45-9c55-8e2a6a1e3c6d",
+		Version:     "1.0.0",
+		Name:        "api",
+		Image:       image,
+		Revision:    revision,
+		Type:        openapi_chaos_client.FUNCTIONTYPE_WORKER,
+		Size:        openapi_chaos_client.FunctionSize{Cpu: 128, Memory: 128},
+		Port:        80,
+		Scaling:     openapi_chaos_client.FunctionScaling{Type: "cpu", Low: 10, High: 80},
+		Deployments: []openapi_chaos_client.FunctionDeployment{},
+		Secrets:     []openapi_chaos_client.FunctionEnv{},
+		Compliance:  []openapi_chaos_client.FunctionCompliance{},
+	}
+}
+
+func TestRollbackResults(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	var mu sync.Mutex
+	var requests []string
+	var restored openapi_chaos_client.Function
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodDelete && strings.HasSuffix(r.URL.Path, "/broken"):
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"message": "unavailable"}`))
+		case r.Method == http.MethodDelete:
+			_, _ = w.Write([]byte(`{"message": "deleted"}`))
+		case r.Method == http.MethodPut:
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&restored))
+			restored.Revision = "3"
+			_ = json.NewEncoder(w).Encode(restored)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	qc := client.QernalAPIClient{APIClient: *openapi_chaos_client.NewAPIClient(&openapi_chaos_client.Configuration{
+		Servers: openapi_chaos_client.ServerConfigurations{{URL: server.URL}},
+	})}
+
+	prior := atomicFunction("updated", "1", "nginx:1.26")
+	results := []functionResult{
+		{Name: "new", Action: actionCreated, ID: "created"},
+		{Name: "api", Action: actionUpdated, ID: "updated", revision: "2", prior: &prior},
+		{Name: "worker", Action: actionUnchanged, ID: "unchanged"},
+		{Name: "cron", Action: actionFailed, Error: "quota exceeded"},
+	}
+
+	failures := rollbackResults(context.Background(), &qc, utils.NewPrinter(), results)
+	assert.Zero(t, failures)
+	assert.Equal(t, []string{"DELETE /functions/created", "PUT /functions/updated"}, requests)
+	assert.Equal(t, "nginx:1.26", restored.Image, "the prior spec is restored")
+	assert.Equal(t, rollbackDeleted, results[0].Rollback)
+	assert.Equal(t, "restored revision 1", results[1].Rollback)
+	assert.Empty(t, results[2].Rollback, "unchanged functions are left alone")
+
+	err := atomicError("apply", results, failures)
+	assert.Equal(t, utils.ExitError, utils.ExitCode(err))
+	assert.EqualError(t, err, "apply failed for 1 of 4 functions, rolled back 2")
+
+	results = []functionResult{
+		{Name: "new", Action: actionCreated, ID: "broken"},
+		{Name: "cron", Action: actionFailed, Error: "quota exceeded"},
+	}
+	failures = rollbackResults(context.Background(), &qc, utils.NewPrinter(), results)
+	assert.Equal(t, 1, failures)
+	assert.True(t, strings.HasPrefix(results[0].Rollback, rollbackFailed+": "), results[0].Rollback)
+
+	err = atomicError("create", results, failures)
+	assert.Equal(t, utils.ExitPartial, utils.ExitCode(err))
+	assert.EqualError(t, err, "create failed for 1 of 2 functions, rolled back 0, 1 could not be rolled back")
+}
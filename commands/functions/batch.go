@@ -0,0 +1,83 @@
+package functions
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/qernal/cli-qernal/pkg/helpers"
+	"github.com/qernal/cli-qernal/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// actionSkipped is the outcome of a function that wasn't attempted because of --fail-fast
+const actionSkipped = "skipped"
+
+// addBatchFlags adds --parallel and --fail-fast to a command deploying several functions
+func addBatchFlags(cmd *cobra.Command) {
+	cmd.Flags().Int("parallel", 1, "number of functions to deploy at the same time")
+	cmd.Flags().Bool("fail-fast", false, "stop starting new functions once one fails")
+}
+
+// batchOptions returns the values of --parallel and --fail-fast
+func batchOptions(cmd *cobra.Command) (parallel int, failFast bool, err error) {
+	parallel, _ = cmd.Flags().GetInt("parallel")
+	failFast, _ = cmd.Flags().GetBool("fail-fast")
+	if parallel < 1 {
+		return 0, false, utils.UsageError("--parallel must be at least 1")
+	}
+	return parallel, failFast, nil
+}
+
+// runBatch calls run for every document, at most parallel at a time, and times each call. With
+// failFast, documents that haven't started when a call fails are skipped, calls already running
+// are left to finish. Results are in the order of documents.
+func runBatch(ctx context.Context, documents []helpers.FunctionDocument, parallel int, failFast bool, run func(context.Context, helpers.FunctionDocument) functionResult) []functionResult {
+	results := make([]functionResult, len(documents))
+	slots := make(chan struct{}, parallel)
+	var failed atomic.Bool
+	var wg sync.WaitGroup
+
+	for i, doc := range documents {
+		slots <- struct{}{}
+		if failFast && failed.Load() {
+			<-slots
+			results[i] = functionResult{
+				Name:   doc.Body.Name,
+				Source: doc.Position(),
+				Action: actionSkipped,
+				Error:  "not attempted after an earlier failure",
+			}
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, doc helpers.FunctionDocument) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			start := time.Now()
+			result := run(ctx, doc)
+			result.Duration = time.Since(start).Round(time.Millisecond).String()
+			if result.Action == actionFailed {
+				failed.Store(true)
+			}
+			results[i] = result
+		}(i, doc)
+	}
+
+	wg.Wait()
+	return results
+}
+
+// batchError summarises results, functions that failed or were skipped count as failed
+func batchError(action string, results []functionResult) error {
+	failed := 0
+	for _, result := range results {
+		if result.Action == actionFailed || result.Action == actionSkipped {
+			failed++
+		}
+	}
+	return utils.BatchError(action, failed, len(results))
+}
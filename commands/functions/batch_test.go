@@ -0,0 +1,77 @@
+package functions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/qernal/cli-qernal/pkg/helpers"
+	"github.com/qernal/cli-qernal/pkg/utils"
+	openapi_chaos_client "github.com/qernal/openapi-chaos-go-client"
+	"github.com/stretchr/testify/assert"
+)
+
+func batchDocuments(n int) []helpers.FunctionDocument {
+	documents := make([]helpers.FunctionDocument, n)
+	for i := range documents {
+		documents[i] = helpers.FunctionDocument{
+			Source: "functions.yaml",
+			Index:  i + 1,
+			Body:   openapi_chaos_client.FunctionBody{Name: fmt.Sprintf("fn-%d", i)},
+		}
+	}
+	return documents
+}
+
+func TestRunBatch(t *testing.T) {
+	var running, most atomic.Int32
+	results := runBatch(context.Background(), batchDocuments(6), 3, false, func(ctx context.Context, doc helpers.FunctionDocument) functionResult {
+		now := running.Add(1)
+		defer running.Add(-1)
+		for {
+			seen := most.Load()
+			if now <= seen || most.CompareAndSwap(seen, now) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		result := functionResult{Name: doc.Body.Name, Action: actionCreated}
+		if doc.Index == 2 {
+			result.fail(errors.New("quota exceeded"))
+		}
+		return result
+	})
+
+	assert.LessOrEqual(t, most.Load(), int32(3))
+	for i, result := range results {
+		assert.Equal(t, fmt.Sprintf("fn-%d", i), result.Name, "results are in document order")
+		assert.NotEmpty(t, result.Duration)
+	}
+	assert.Equal(t, actionFailed, results[1].Action)
+	assert.Equal(t, actionCreated, results[5].Action, "later functions are attempted after a failure")
+
+	err := batchError("create", results)
+	assert.Equal(t, utils.ExitPartial, utils.ExitCode(err))
+	assert.EqualError(t, err, "create failed for 1 of 6 resources")
+}
+
+func TestRunBatchFailFast(t *testing.T) {
+	results := runBatch(context.Background(), batchDocuments(4), 1, true, func(ctx context.Context, doc helpers.FunctionDocument) functionResult {
+		result := functionResult{Name: doc.Body.Name, Action: actionCreated}
+		if doc.Index == 2 {
+			result.fail(errors.New("quota exceeded"))
+		}
+		return result
+	})
+
+	var actions []string
+	for _, result := range results {
+		actions = append(actions, result.Action)
+	}
+	assert.Equal(t, []string{actionCreated, actionFailed, actionSkipped, actionSkipped}, actions)
+	assert.EqualError(t, batchError("create", results), "create failed for 3 of 4 resources")
+}
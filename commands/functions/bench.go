@@ -0,0 +1,250 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/qernal/cli-qernal/charm"
+	"github.com/qernal/cli-qernal/commands/auth"
+	"github.com/qernal/cli-qernal/pkg/client"
+	"github.com/qernal/cli-qernal/pkg/common"
+	"github.com/qernal/cli-qernal/pkg/helpers"
+	"github.com/qernal/cli-qernal/pkg/utils"
+	openapi_chaos_client "github.com/qernal/openapi-chaos-go-client"
+	"github.com/spf13/cobra"
+)
+
+// benchBarWidth is the width of the largest bar of the latency histogram
+const benchBarWidth = 40
+
+func NewBenchCmd(printer *utils.Printer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bench",
+		Short: "Load test a function over HTTP",
+		Long: `Send requests to a function at a steady rate for a while and report the latency percentiles and
+histogram, the status codes and the errors. A request is counted as missed instead of queued when
+all --concurrency workers are busy, raise --concurrency if any are. The requests are built like
+functions invoke, --url load tests another address instead, e.g. a local server.
+
+With --metrics the requests the function's metrics recorded for the run are shown next to the
+status codes, metrics can take a minute to show up.`,
+		Example: `  qernal functions bench --project <project name> --function api --path / --rps 200 --duration 60s --concurrency 50
+  qernal functions bench --url http://localhost:8080 --path /health --rps 500 --duration 10s`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				return utils.UsageError("no arguments expected")
+			}
+
+			opts, err := benchOptions(cmd)
+			if err != nil {
+				return err
+			}
+			withMetrics, _ := cmd.Flags().GetBool("metrics")
+			if name, _ := cmd.Flags().GetString("function"); withMetrics && name == "" {
+				return utils.UsageError("--metrics requires --function")
+			}
+
+			ctx := context.Background()
+			request, function, err := functionRequest(ctx, cmd, printer)
+			if err != nil {
+				return err
+			}
+
+			timeout, _ := cmd.Flags().GetDuration("timeout")
+			httpClient := &http.Client{
+				Timeout:   timeout,
+				Transport: &http.Transport{Proxy: http.ProxyFromEnvironment, MaxIdleConnsPerHost: opts.Concurrency},
+			}
+			if common.OutputFormat != "json" {
+				printer.PrintWarning(fmt.Sprintf("sending %s %s for %s", request.Method, request.URL, opts.Duration))
+			}
+			report := helpers.Bench(ctx, httpClient, request, opts)
+
+			if withMetrics {
+				report.FunctionRequests, err = benchMetrics(ctx, printer, function, report)
+				if err != nil {
+					printer.PrintWarning(fmt.Sprintf("unable to retrieve function metrics, %s", err.Error()))
+				} else if len(report.FunctionRequests) == 0 {
+					printer.PrintWarning("no function metrics for the run yet, they can take a minute to show up")
+				}
+			}
+
+			if common.OutputFormat == "json" {
+				printer.PrintResource(utils.FormatOutput(report, common.OutputFormat))
+			} else {
+				printer.PrintResource(renderBenchReport(report))
+			}
+
+			if len(report.StatusCodes) == 0 {
+				return utils.NewError(utils.ExitError, fmt.Sprintf("none of the %d requests got a response", report.Requests))
+			}
+			return nil
+		},
+	}
+
+	addRequestFlags(cmd)
+	cmd.Flags().Int("rps", 10, "requests to start per second, 0 sends them as fast as --concurrency allows")
+	cmd.Flags().Duration("duration", 10*time.Second, "how long to send requests for")
+	cmd.Flags().Int("concurrency", 10, "most requests in flight at the same time")
+	cmd.Flags().Duration("timeout", 30*time.Second, "how long to wait for each response")
+	cmd.Flags().Bool("metrics", false, "show the requests the function's metrics recorded during the run, requires --function")
+
+	return cmd
+}
+
+// benchOptions returns the values of --rps, --duration and --concurrency
+func benchOptions(cmd *cobra.Command) (helpers.BenchOptions, error) {
+	var opts helpers.BenchOptions
+	opts.Rate, _ = cmd.Flags().GetInt("rps")
+	opts.Duration, _ = cmd.Flags().GetDuration("duration")
+	opts.Concurrency, _ = cmd.Flags().GetInt("concurrency")
+
+	switch {
+	case opts.Rate < 0:
+		return opts, utils.UsageError("--rps can't be negative")
+	case opts.Rate > int(time.Second):
+		return opts, utils.UsageError(fmt.Sprintf("--rps can be at most %d", int(time.Second)))
+	case opts.Duration <= 0:
+		return opts, utils.UsageError("--duration must be greater than zero")
+	case opts.Concurrency < 1:
+		return opts, utils.UsageError("--concurrency must be at least 1")
+	}
+	return opts, nil
+}
+
+// benchMetrics returns the requests the function's httprequests metrics recorded during the run, by
+// status code
+func benchMetrics(ctx context.Context, printer *utils.Printer, function *openapi_chaos_client.Function, report *helpers.BenchReport) (map[string]int, error) {
+	token, err := auth.GetQernalToken()
+	if err != nil {
+		return nil, err
+	}
+	qc, err := client.New(ctx, nil, nil, token)
+	if err != nil {
+		return nil, err
+	}
+
+	after := report.Started.Format(time.RFC3339)
+	before := report.Finished.Add(time.Second).Format(time.RFC3339)
+	metricResp, httpRes, err := qc.MetricsAPI.MetricsAggregationsList(ctx, "httprequests").
+		FProject(function.ProjectId).
+		FFunction(function.Id).
+		FHistogramInterval(60).
+		FTimestamps(openapi_chaos_client.LogsListFTimestampsParameter{
+			After:  &after,
+			Before: &before,
+		}).
+		Execute()
+	if err != nil {
+		printer.Logger.Debug("Metrics collection failed ",
+			slog.String("error", err.Error()),
+			slog.Any("response", httpRes))
+		return nil, err
+	}
+
+	requests := map[string]int{}
+	if metricResp.MetricHttpAggregation == nil {
+		return requests, nil
+	}
+	for _, bucket := range metricResp.MetricHttpAggregation.GetHttpCodes().Buckets {
+		if bucket.Key != nil && bucket.DocCount != nil {
+			requests[*bucket.Key] += int(*bucket.DocCount)
+		}
+	}
+	return requests, nil
+}
+
+// renderBenchReport renders a bench report as a summary, the latency histogram and tables of the
+// status codes and errors
+func renderBenchReport(report *helpers.BenchReport) string {
+	errors := 0
+	for _, count := range report.Errors {
+		errors += count
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d requests in %s, %.1f/s, %d errors", report.Requests, report.Duration, report.Rate, errors)
+	if report.Missed > 0 {
+		fmt.Fprintf(&b, ", %d missed with all workers busy", report.Missed)
+	}
+	summary := charm.SuccessStyle
+	if errors > 0 || report.Missed > 0 {
+		summary = charm.WarningStyle
+	}
+	parts := []string{summary.Render(b.String())}
+
+	if len(report.Histogram) > 0 {
+		latency := report.Latency
+		parts = append(parts, charm.RenderSummaryTable(
+			[]string{"Min", "Mean", "P50", "P90", "P99", "Max"},
+			[][]string{{latency.Min, latency.Mean, latency.P50, latency.P90, latency.P99, latency.Max}},
+		))
+		parts = append(parts, renderLatencyHistogram(report.Histogram))
+	}
+
+	codes := make([]int, 0, len(report.StatusCodes))
+	for code := range report.StatusCodes {
+		codes = append(codes, code)
+	}
+	for key := range report.FunctionRequests {
+		if code, err := strconv.Atoi(key); err == nil && !slices.Contains(codes, code) {
+			codes = append(codes, code)
+		}
+	}
+	slices.Sort(codes)
+	if len(codes) > 0 {
+		columns := []string{"Status", "Responses"}
+		if report.FunctionRequests != nil {
+			columns = append(columns, "Function metrics")
+		}
+		var rows [][]string
+		for _, code := range codes {
+			row := []string{fmt.Sprintf("%d %s", code, http.StatusText(code)), strconv.Itoa(report.StatusCodes[code])}
+			if report.FunctionRequests != nil {
+				row = append(row, strconv.Itoa(report.FunctionRequests[strconv.Itoa(code)]))
+			}
+			rows = append(rows, row)
+		}
+		parts = append(parts, charm.RenderSummaryTable(columns, rows))
+	}
+
+	if len(report.Errors) > 0 {
+		messages := make([]string, 0, len(report.Errors))
+		for message := range report.Errors {
+			messages = append(messages, message)
+		}
+		slices.SortFunc(messages, func(a, b string) int { return report.Errors[b] - report.Errors[a] })
+		var rows [][]string
+		for _, message := range messages {
+			rows = append(rows, []string{message, strconv.Itoa(report.Errors[message])})
+		}
+		parts = append(parts, charm.RenderSummaryTable([]string{"Error", "Count"}, rows))
+	}
+
+	return strings.Join(parts, "\n")
+}
+
+// renderLatencyHistogram renders the latency buckets as horizontal bars
+func renderLatencyHistogram(buckets []helpers.LatencyBucket) string {
+	most, width := 0, 0
+	for _, bucket := range buckets {
+		most = max(most, bucket.Count)
+		width = max(width, len(bucket.UpTo))
+	}
+
+	var b strings.Builder
+	for _, bucket := range buckets {
+		bar := strings.Repeat("█", bucket.Count*benchBarWidth/max(most, 1))
+		if bar == "" && bucket.Count > 0 {
+			bar = "▏"
+		}
+		fmt.Fprintf(&b, "≤ %*s %s %d\n", width, bucket.UpTo, charm.ChangedStyle.Render(bar), bucket.Count)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
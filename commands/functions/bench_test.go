@@ -0,0 +1,47 @@
+package functions
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/qernal/cli-qernal/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBenchURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	defer server.Close()
+
+	printer := utils.NewPrinter()
+	var out, errOut bytes.Buffer
+	printer.SetOut(&out)
+	printer.SetErr(&errOut)
+
+	cmd := NewBenchCmd(printer)
+	cmd.SetArgs([]string{"--url", server.URL, "-X", "POST", "--rps", "50", "--duration", "200ms", "--concurrency", "2"})
+	require.NoError(t, cmd.Execute())
+
+	output := ansi.Strip(out.String())
+	assert.Contains(t, output, "P99")
+	assert.Contains(t, output, "200 OK")
+	assert.NotContains(t, output, "405")
+	assert.Contains(t, errOut.String(), "sending POST "+server.URL+"/")
+
+	for _, args := range [][]string{
+		{"--url", server.URL, "--concurrency", "0"},
+		{"--url", server.URL, "--duration", "0s"},
+		{"--url", server.URL, "--metrics"},
+	} {
+		cmd = NewBenchCmd(printer)
+		cmd.SetArgs(args)
+		assert.Equal(t, utils.ExitUsage, utils.ExitCode(cmd.Execute()), args)
+	}
+}
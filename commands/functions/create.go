@@ -2,9 +2,6 @@ package functions
 
 import (
 	"context"
-	"errors"
-	"fmt"
-	"log/slog"
 
 	"github.com/qernal/cli-qernal/charm"
 	"github.com/qernal/cli-qernal/commands/auth"
@@ -18,7 +15,13 @@ func NewCreateCmd(printer *utils.Printer) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "create",
 		Aliases: []string{"new"},
-		Example: "qernal functions create -f function.yaml",
+		Example: `  qernal functions create -f function.yaml
+
+  # Create every function defined in a directory and a second file
+  qernal functions create -f functions/ -f extra.yaml
+
+  # Create up to 8 functions at a time and stop at the first failure
+  qernal functions create -f functions/ --parallel 8 --fail-fast`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
 
@@ -32,40 +35,61 @@ func NewCreateCmd(printer *utils.Printer) *cobra.Command {
 				return charm.RenderError("error creating qernal client", err)
 			}
 
-			file, _ := cmd.Flags().GetString("file")
+			parallel, failFast, err := batchOptions(cmd)
+			if err != nil {
+				return err
+			}
 
-			qFunctions, err := helpers.ParseFunctionConfig(file, printer)
+			documents, err := loadDefinitions(cmd)
 			if err != nil {
-				return charm.RenderError("unable to parse function config", err)
+				return err
+			}
+			if err := resolveProjects(cmd, &qc, documents); err != nil {
+				return err
+			}
+			if err := resolveSecrets(ctx, cmd, &qc, printer, documents); err != nil {
+				return err
 			}
 
-			for _, function := range qFunctions {
-				qFunc, httpRes, err := qc.FunctionsAPI.FunctionsCreate(ctx).FunctionBody(function).Execute()
+			if helpers.DryRun(cmd) {
+				requests := make([]helpers.DryRunRequest, 0, len(documents))
+				for _, doc := range documents {
+					requests = append(requests, helpers.CreateFunctionRequest(doc.Body))
+				}
+				return helpers.PrintDryRun(printer, requests...)
+			}
+
+			atomic, _ := cmd.Flags().GetBool("atomic")
+			results := runBatch(ctx, documents, parallel, failFast || atomic, func(ctx context.Context, doc helpers.FunctionDocument) functionResult {
+				result := functionResult{Name: doc.Body.Name, Source: doc.Position()}
+				qFunc, err := helpers.CreateFunction(ctx, &qc, printer, doc.Body)
 				if err != nil {
-					resData, _ := client.ParseResponseData(httpRes)
-					if data, ok := resData.(map[string]interface{}); ok {
-						if innerData, ok := data["data"].(map[string]interface{}); ok {
-							if nameErr, ok := innerData["name"].(string); ok {
-								err = errors.New(nameErr)
-							}
-						}
-					}
-					printer.Logger.Debug("unable to create function, request failed",
-						slog.String("error", err.Error()),
-						slog.Any("response", resData))
-					return printer.RenderError(fmt.Sprintf("unable to create function with name %s. Request failed with", qFunc.Name), err)
+					result.fail(err)
+					return result
 				}
-				printer.PrintResource(charm.SuccessStyle.Render(fmt.Sprintf("created function %s", function.Name)))
+				result.Action, result.ID, result.revision = actionCreated, qFunc.Id, qFunc.Revision
+				return result
+			})
+
+			if atomic && batchError("create", results) != nil {
+				rollbackFailures := rollbackResults(ctx, &qc, printer, results)
+				printResults(printer, results)
+				return atomicError("create", results, rollbackFailures)
 			}
 
-			printer.PrintResource(charm.SuccessStyle.Render("Success.\nrun qernal function ls --project-id=<project-id> to view all functions"))
+			printResults(printer, results)
 
-			return nil
+			return batchError("create", results)
 		},
 	}
 
-	cmd.Flags().StringVarP(&functionFile, "file", "f", "", "path to function definition file (yaml)")
+	addDefinitionFlags(cmd)
+	addSecretCheckFlag(cmd)
+	helpers.AddDryRunFlag(cmd)
+	addBatchFlags(cmd)
+	addAtomicFlag(cmd)
 	_ = cmd.MarkFlagRequired("file")
+	utils.AddQuietFlag(cmd)
 
 	return cmd
 }
@@ -0,0 +1,84 @@
+package functions
+
+import (
+	"context"
+
+	"github.com/qernal/cli-qernal/charm"
+	"github.com/qernal/cli-qernal/pkg/client"
+	"github.com/qernal/cli-qernal/pkg/helpers"
+	"github.com/qernal/cli-qernal/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// fileFlagUsage describes the repeatable --file flag of commands reading function definitions
+const fileFlagUsage = "function definition file (yaml), directory or glob, - reads stdin, can be repeated, later files overlay earlier ones"
+
+// addDefinitionFlags adds -f/--file and --var to a command reading function definitions
+func addDefinitionFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVarP(&functionFiles, "file", "f", nil, fileFlagUsage)
+	addVarFlag(cmd)
+}
+
+// addVarFlag adds --var to set ${VAR} references in function definitions
+func addVarFlag(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVar(&functionVars, "var", nil, "set a ${VAR} in function definitions as key=value, overrides the environment, can be repeated")
+}
+
+// definitionVariables returns the variables set with --var
+func definitionVariables(cmd *cobra.Command) (helpers.Variables, error) {
+	pairs, _ := cmd.Flags().GetStringArray("var")
+	vars, err := helpers.ParseVariables(pairs)
+	if err != nil {
+		return nil, utils.UsageError(err.Error())
+	}
+	return vars, nil
+}
+
+// loadDefinitions reads the function definitions given with --file, interpolated with --var and
+// merged with their overlays
+func loadDefinitions(cmd *cobra.Command) ([]helpers.FunctionDocument, error) {
+	vars, err := definitionVariables(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	files, _ := cmd.Flags().GetStringArray("file")
+	documents, err := helpers.LoadFunctionDocuments(files, cmd.InOrStdin(), vars)
+	if err != nil {
+		return nil, charm.RenderError("unable to parse function config", err)
+	}
+	return documents, nil
+}
+
+// resolveProjects fills in the project of every definition, --project-id and --project override
+// the project or project_id set in the definitions
+func resolveProjects(cmd *cobra.Command, qc *client.QernalAPIClient, documents []helpers.FunctionDocument) error {
+	projectID, _ := cmd.Flags().GetString("project-id")
+	project, _ := cmd.Flags().GetString("project")
+	if projectID != "" || project != "" {
+		if err := helpers.ValidateProjectFlags(cmd); err != nil {
+			return err
+		}
+		var err error
+		if projectID, err = helpers.GetProjectID(cmd, qc); err != nil {
+			return err
+		}
+	}
+
+	return helpers.ResolveDocumentProjects(documents, projectID, func(name string) (string, error) {
+		project, err := qc.GetProjectByName(name)
+		return project.Id, err
+	})
+}
+
+// addSecretCheckFlag adds --skip-secret-check to a command submitting function definitions
+func addSecretCheckFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool("skip-secret-check", false, "don't check that referenced secrets exist before submitting")
+}
+
+// resolveSecrets expands the short secret references of documents and checks every reference
+// unless --skip-secret-check is set
+func resolveSecrets(ctx context.Context, cmd *cobra.Command, qc *client.QernalAPIClient, printer *utils.Printer, documents []helpers.FunctionDocument) error {
+	skip, _ := cmd.Flags().GetBool("skip-secret-check")
+	return helpers.ResolveFunctionSecrets(ctx, qc, printer, documents, !skip)
+}
@@ -2,7 +2,6 @@ package functions
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"log/slog"
 
@@ -16,29 +15,29 @@ import (
 
 func NewDeleteCmd(printer *utils.Printer) *cobra.Command {
 	var functionID string
-	var functionFile string
+	var functionFiles []string
 	var projectID string
 
 	cmd := &cobra.Command{
 		Use:     "delete",
 		Aliases: []string{"rm"},
-		Example: "qernal function delete --function <function id>\nqernal function delete --file function.yaml --project-id <project-id>",
+		Example: "qernal function delete --function <function id>\nqernal function delete --function <function name> --project-id <project-id>\nqernal function delete --file function.yaml --project-id <project-id>",
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) > 0 {
-				return errors.New("no arguments expected")
+				return utils.UsageError("no arguments expected")
 			}
 
 			// Check if either function ID or file is provided
 			hasFunction, _ := cmd.Flags().GetString("function")
-			hasFile, _ := cmd.Flags().GetString("file")
+			hasFile, _ := cmd.Flags().GetStringArray("file")
 			hasProject, _ := cmd.Flags().GetString("project-id")
 
-			if hasFunction == "" && hasFile == "" {
-				return errors.New("either --function or --file must be specified")
+			if hasFunction == "" && len(hasFile) == 0 {
+				return utils.UsageError("either --function or --file must be specified")
 			}
 
-			if hasFile != "" && hasProject == "" {
-				return errors.New("when using --file, --project-id must also be specified")
+			if len(hasFile) > 0 && hasProject == "" {
+				return utils.UsageError("when using --file, --project-id must also be specified")
 			}
 
 			return nil
@@ -56,13 +55,13 @@ func NewDeleteCmd(printer *utils.Printer) *cobra.Command {
 			}
 
 			// Check if we're deleting by file or by function ID
-			file, _ := cmd.Flags().GetString("file")
-			if file != "" {
-				// Get functions from the file
-				qFunctions, err := helpers.ParseFunctionConfig(file, printer)
+			if len(functionFiles) > 0 {
+				// Get functions from the files
+				documents, err := loadDefinitions(cmd)
 				if err != nil {
-					return charm.RenderError("unable to parse function config", err)
+					return err
 				}
+				qFunctions := helpers.FunctionBodies(documents)
 
 				// Get list of functions from the project
 				functions, httpRes, err := qc.FunctionsAPI.ProjectsFunctionsList(ctx, projectID).Execute()
@@ -81,8 +80,14 @@ func NewDeleteCmd(printer *utils.Printer) *cobra.Command {
 				}
 
 				// Delete each function that matches
+				failed := 0
+				var requests []helpers.DryRunRequest
 				for _, function := range qFunctions {
 					if id, exists := functionMap[function.Name]; exists {
+						if helpers.DryRun(cmd) {
+							requests = append(requests, helpers.DeleteFunctionRequest(id))
+							continue
+						}
 						_, httpRes, err := qc.FunctionsAPI.FunctionsDelete(ctx, id).Execute()
 						if err != nil {
 							resData, _ := client.ParseResponseData(httpRes)
@@ -90,26 +95,42 @@ func NewDeleteCmd(printer *utils.Printer) *cobra.Command {
 								slog.String("error", err.Error()),
 								slog.Any("response", resData),
 								slog.String("function_name", function.Name))
-							printer.PrintResource(charm.WarningStyle.Render(
-								fmt.Sprintf("failed to delete function %s: %s", function.Name, err.Error())))
+							printer.PrintWarning(fmt.Sprintf("failed to delete function %s: %s", function.Name, err.Error()))
+							failed++
 							continue
 						}
 						printer.PrintResource(charm.SuccessStyle.Render(
 							fmt.Sprintf("deleted function %s", function.Name)))
 					} else {
-						printer.PrintResource(charm.WarningStyle.Render(
-							fmt.Sprintf("function %s not found in project", function.Name)))
+						printer.PrintWarning(fmt.Sprintf("function %s not found in project", function.Name))
+						failed++
+					}
+				}
+				if helpers.DryRun(cmd) {
+					if err := helpers.PrintDryRun(printer, requests...); err != nil {
+						return err
 					}
+					return utils.BatchError("delete", failed, len(qFunctions))
+				}
+				if err := utils.BatchError("delete", failed, len(qFunctions)); err != nil {
+					return err
 				}
 			} else {
-				// Delete by function ID
+				// Delete by function ID or name
+				functionID, err := functionFlagID(cmd, &qc, printer)
+				if err != nil {
+					return err
+				}
+				if helpers.DryRun(cmd) {
+					return helpers.PrintDryRun(printer, helpers.DeleteFunctionRequest(functionID))
+				}
 				_, httpRes, err := qc.FunctionsAPI.FunctionsDelete(ctx, functionID).Execute()
 				if err != nil {
 					resData, _ := client.ParseResponseData(httpRes)
 					if data, ok := resData.(map[string]interface{}); ok {
 						if innerData, ok := data["data"].(map[string]interface{}); ok {
 							if nameErr, ok := innerData["name"].(string); ok {
-								return charm.RenderError("unable to delete function: ", errors.New(nameErr))
+								return charm.RenderError("unable to delete function: ", client.NewResponseError(nameErr, err))
 							}
 						}
 					}
@@ -127,8 +148,10 @@ func NewDeleteCmd(printer *utils.Printer) *cobra.Command {
 		},
 	}
 
-	cmd.Flags().StringVar(&functionID, "function", "", "function id")
-	cmd.Flags().StringVar(&functionFile, "file", "", "path to function definition file (yaml)")
+	cmd.Flags().StringVar(&functionID, "function", "", functionFlagUsage)
+	cmd.Flags().StringArrayVar(&functionFiles, "file", nil, fileFlagUsage)
+	addVarFlag(cmd)
+	helpers.AddDryRunFlag(cmd)
 	cmd.Flags().StringVar(&projectID, "project-id", "", "project id (required when using --file)")
 
 	return cmd
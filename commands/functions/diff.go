@@ -0,0 +1,122 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/qernal/cli-qernal/charm"
+	"github.com/qernal/cli-qernal/commands/auth"
+	"github.com/qernal/cli-qernal/pkg/client"
+	"github.com/qernal/cli-qernal/pkg/common"
+	"github.com/qernal/cli-qernal/pkg/helpers"
+	"github.com/qernal/cli-qernal/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// functionDiff is the difference between a live function and its definition
+type functionDiff struct {
+	Name   string `json:"name"`
+	Status string `json:"status"` // missing, changed or unchanged
+	Diff   string `json:"diff,omitempty"`
+}
+
+func NewDiffCmd(printer *utils.Printer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Show how live functions differ from a definition file",
+		Long: `Compare every function in a definition file with the live function of the same name and
+print a unified diff of what functions apply would change. Exits with code 9 when any function differs.`,
+		Example: "qernal functions diff -f functions.yaml",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			token, err := auth.GetQernalToken()
+			if err != nil {
+				return charm.RenderError("error creating qernal client", err)
+			}
+
+			qc, err := client.New(ctx, nil, nil, token)
+			if err != nil {
+				return charm.RenderError("error creating qernal client", err)
+			}
+
+			documents, err := loadDefinitions(cmd)
+			if err != nil {
+				return err
+			}
+
+			if err := resolveProjects(cmd, &qc, documents); err != nil {
+				return err
+			}
+			// compare the references that would be submitted
+			if err := helpers.ResolveFunctionSecrets(ctx, &qc, printer, documents, false); err != nil {
+				return err
+			}
+
+			liveFunctions, err := liveFunctionsByName(ctx, &qc, printer, documents)
+			if err != nil {
+				return err
+			}
+
+			var diffs []functionDiff
+			drifted := 0
+			for _, doc := range documents {
+				function := doc.Body
+				desired, err := helpers.FunctionBodyYAML(function)
+				if err != nil {
+					return charm.RenderError(fmt.Sprintf("unable to compare %s", doc.Position()), err)
+				}
+
+				result := functionDiff{Name: function.Name, Status: "unchanged"}
+				current := ""
+				if live, ok := liveFunctions[function.ProjectId][function.Name]; ok {
+					current, err = helpers.FunctionBodyYAML(helpers.FunctionToBody(live))
+					if err != nil {
+						return charm.RenderError("unable to compare functions", err)
+					}
+				} else {
+					result.Status = "missing"
+				}
+
+				result.Diff = helpers.UnifiedDiff("live/"+function.Name, "local/"+function.Name, current, desired)
+				if result.Diff != "" {
+					drifted++
+					if result.Status != "missing" {
+						result.Status = "changed"
+					}
+				}
+				diffs = append(diffs, result)
+			}
+
+			switch {
+			case common.Quiet:
+				for _, result := range diffs {
+					if result.Diff != "" {
+						printer.PrintIDs(result.Name)
+					}
+				}
+			case common.OutputFormat == "json":
+				printer.PrintResource(utils.FormatOutput(diffs, common.OutputFormat))
+			case drifted == 0:
+				printer.PrintResource(charm.SuccessStyle.Render("no differences, all functions match their definition"))
+			default:
+				for _, result := range diffs {
+					if result.Diff != "" {
+						printer.PrintResource(charm.RenderDiff(result.Diff))
+					}
+				}
+			}
+
+			if drifted > 0 {
+				return utils.NewError(utils.ExitDrift, fmt.Sprintf("%d of %d functions differ from their definition", drifted, len(documents)))
+			}
+			return nil
+		},
+	}
+
+	addDefinitionFlags(cmd)
+	_ = cmd.MarkFlagRequired("file")
+	utils.AddQuietFlag(cmd)
+
+	return cmd
+}
@@ -0,0 +1,40 @@
+package functions
+
+import (
+	"strings"
+
+	"github.com/qernal/cli-qernal/charm"
+	"github.com/qernal/cli-qernal/pkg/common"
+	"github.com/qernal/cli-qernal/pkg/helpers"
+	"github.com/qernal/cli-qernal/pkg/utils"
+	openapi_chaos_client "github.com/qernal/openapi-chaos-go-client"
+	"github.com/spf13/cobra"
+)
+
+// addExportFlag adds --export to a command printing live functions
+func addExportFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool("export", false, "print the functions as a yaml definition file that can be passed to functions apply")
+}
+
+// exportRequested reports whether the functions should be printed as a definition file, yaml output
+// is only available for exports
+func exportRequested(cmd *cobra.Command) (bool, error) {
+	export, _ := cmd.Flags().GetBool("export")
+	switch {
+	case export && common.OutputFormat == "json":
+		return false, utils.UsageError("--export writes yaml, it can't be combined with -o json")
+	case !export && common.OutputFormat == "yaml":
+		return false, utils.UsageError("yaml output is only available with --export")
+	}
+	return export, nil
+}
+
+// printExport prints functions as a multi-document definition file
+func printExport(printer *utils.Printer, functions []openapi_chaos_client.Function) error {
+	out, err := helpers.ExportFunctions(functions)
+	if err != nil {
+		return charm.RenderError("unable to export functions", err)
+	}
+	printer.PrintResource(strings.TrimSuffix(out, "\n"))
+	return nil
+}
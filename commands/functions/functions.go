@@ -2,17 +2,28 @@ package functions
 
 import (
 	"github.com/qernal/cli-qernal/charm"
+	"github.com/qernal/cli-qernal/pkg/client"
+	"github.com/qernal/cli-qernal/pkg/helpers"
 	"github.com/qernal/cli-qernal/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
 var (
-	functionFile string
-	functionID   string
-	functionName string
-	watch        bool
+	functionFiles []string
+	functionVars  []string
+	functionID    string
+	functionName  string
 )
 
+// functionFlagUsage describes the --function flag, which accepts an id or a name
+const functionFlagUsage = "function id or name, names are looked up in --project-id or --project"
+
+// functionFlagID returns the id of the function given with --function
+func functionFlagID(cmd *cobra.Command, qc *client.QernalAPIClient, printer *utils.Printer) (string, error) {
+	function, _ := cmd.Flags().GetString("function")
+	return helpers.ResolveFunctionID(cmd, qc, printer, function)
+}
+
 var FunctionCmd = &cobra.Command{
 	Use:     "functions",
 	Short:   "Manage your projects",
@@ -35,4 +46,16 @@ func init() {
 	FunctionCmd.AddCommand(NewGetCmd(printer))
 	FunctionCmd.AddCommand(NewDeleteCmd(printer))
 	FunctionCmd.AddCommand(NewMetricsCmd(printer))
+	FunctionCmd.AddCommand(NewApplyCmd(printer))
+	FunctionCmd.AddCommand(NewDiffCmd(printer))
+	FunctionCmd.AddCommand(NewValidateCmd(printer))
+	FunctionCmd.AddCommand(NewHistoryCmd(printer))
+	FunctionCmd.AddCommand(NewRollbackCmd(printer))
+	FunctionCmd.AddCommand(NewScaleCmd(printer))
+	FunctionCmd.AddCommand(NewSetCmd(printer))
+	FunctionCmd.AddCommand(NewWaitCmd(printer))
+	FunctionCmd.AddCommand(NewInitCmd(printer))
+	FunctionCmd.AddCommand(NewPromoteCmd(printer))
+	FunctionCmd.AddCommand(NewInvokeCmd(printer))
+	FunctionCmd.AddCommand(NewBenchCmd(printer))
 }
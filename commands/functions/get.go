@@ -2,13 +2,13 @@ package functions
 
 import (
 	"context"
-	"errors"
 
 	"github.com/qernal/cli-qernal/charm"
 	"github.com/qernal/cli-qernal/commands/auth"
 	"github.com/qernal/cli-qernal/pkg/client"
 	"github.com/qernal/cli-qernal/pkg/common"
 	"github.com/qernal/cli-qernal/pkg/utils"
+	openapi_chaos_client "github.com/qernal/openapi-chaos-go-client"
 	"github.com/spf13/cobra"
 )
 
@@ -16,17 +16,26 @@ func NewGetCmd(printer *utils.Printer) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "get",
 		Aliases: []string{"get"},
-		Example: "qernal function get --function <function ID>",
-		Short:   "Get detailed information about a function ",
+		Example: `  qernal function get --function <function ID>
+  qernal function get --project <project name> --function <function name>
+
+  # Print the function as a definition file for functions apply
+  qernal function get --function <function ID> --export > function.yaml`,
+		Short: "Get detailed information about a function ",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) > 0 {
-				return charm.RenderError("No arguments expected")
+				return utils.UsageError("no arguments expected")
+			}
+
+			export, err := exportRequested(cmd)
+			if err != nil {
+				return err
 			}
 
 			ctx := context.Background()
 			token, err := auth.GetQernalToken()
 			if err != nil {
-				return charm.RenderError("unable to retreive qernal token, run qernal auth login if you haven't")
+				return charm.RenderError("unable to retreive qernal token, run qernal auth login if you haven't", err)
 
 			}
 
@@ -35,42 +44,53 @@ func NewGetCmd(printer *utils.Printer) *cobra.Command {
 				return charm.RenderError("error creating qernal client", err)
 			}
 
-			functionID, _ := cmd.Flags().GetString("function")
-			qFunc, httpRes, err := qc.FunctionsAPI.FunctionsGet(ctx, functionID).Execute()
+			functionID, err := functionFlagID(cmd, &qc, printer)
 			if err != nil {
-				resData, _ := client.ParseResponseData(httpRes)
-				if data, ok := resData.(map[string]interface{}); ok {
-					if innerData, ok := data["data"].(map[string]interface{}); ok {
-						if nameErr, ok := innerData["name"].(string); ok {
-							return printer.RenderError("unable to find function", errors.New(nameErr))
+				return err
+			}
+
+			return printer.Watch(func() error {
+				qFunc, httpRes, err := qc.FunctionsAPI.FunctionsGet(ctx, functionID).Execute()
+				if err != nil {
+					resData, _ := client.ParseResponseData(httpRes)
+					if data, ok := resData.(map[string]interface{}); ok {
+						if innerData, ok := data["data"].(map[string]interface{}); ok {
+							if nameErr, ok := innerData["name"].(string); ok {
+								return printer.RenderError("unable to find function", client.NewResponseError(nameErr, err))
+							}
 						}
 					}
+					return charm.RenderError("unable to find function", err)
 				}
-				return charm.RenderError("unable to find function", err)
-			}
-
-			var data interface{}
-			if common.OutputFormat == "json" {
-				data = qFunc
-			} else {
-				// TODO: persist order of this render
 
-				data = map[string]interface{}{
-					"Name":        qFunc.Name,
-					"Project ID":  qFunc.ProjectId,
-					"Function ID": qFunc.Id,
-					"Secrets":     len(qFunc.Secrets),
+				if export {
+					return printExport(printer, []openapi_chaos_client.Function{*qFunc})
 				}
-			}
 
-			response := charm.SuccessStyle.Render(utils.FormatOutput(data, common.OutputFormat))
-			printer.PrintResource(response)
-			return nil
+				var data interface{}
+				if common.OutputFormat == "json" {
+					data = qFunc
+				} else {
+					// TODO: persist order of this render
+
+					data = map[string]interface{}{
+						"Name":        qFunc.Name,
+						"Project ID":  qFunc.ProjectId,
+						"Function ID": qFunc.Id,
+						"Secrets":     len(qFunc.Secrets),
+					}
+				}
 
+				response := charm.SuccessStyle.Render(utils.FormatOutput(data, common.OutputFormat))
+				printer.PrintResource(response)
+				return nil
+			})
 		},
 	}
-	cmd.Flags().StringVarP(&functionID, "function", "f", "", "function id")
+	cmd.Flags().StringVarP(&functionID, "function", "f", "", functionFlagUsage)
+	addExportFlag(cmd)
 	_ = cmd.MarkFlagRequired("function")
+	utils.AddWatchFlags(cmd)
 
 	return cmd
 }
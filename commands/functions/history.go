@@ -0,0 +1,98 @@
+package functions
+
+import (
+	"context"
+	"time"
+
+	"github.com/qernal/cli-qernal/charm"
+	"github.com/qernal/cli-qernal/commands/auth"
+	"github.com/qernal/cli-qernal/pkg/client"
+	"github.com/qernal/cli-qernal/pkg/common"
+	"github.com/qernal/cli-qernal/pkg/helpers"
+	"github.com/qernal/cli-qernal/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+func NewHistoryCmd(printer *utils.Printer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "List the revisions of a function",
+		Long: `List every revision of a function, oldest first, with what changed since the revision before.
+Revisions applied from this machine are also kept in $HOME/.qernal/history, so they can be listed and
+rolled back to even if the API no longer has them. Applied times are only known for those revisions.`,
+		Example: "qernal functions history --project <project name> --function <function name>",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				return utils.UsageError("no arguments expected")
+			}
+
+			ctx := context.Background()
+			token, err := auth.GetQernalToken()
+			if err != nil {
+				return charm.RenderError("unable to retrieve qernal token, run qernal auth login if you haven't", err)
+			}
+
+			qc, err := client.New(ctx, nil, nil, token)
+			if err != nil {
+				return charm.RenderError("error creating qernal client", err)
+			}
+
+			functionID, err := functionFlagID(cmd, &qc, printer)
+			if err != nil {
+				return err
+			}
+
+			qFunc, httpRes, err := qc.FunctionsAPI.FunctionsGet(ctx, functionID).Execute()
+			if err != nil {
+				return helpers.FunctionRequestError(printer, "unable to find function", httpRes, err)
+			}
+
+			revisions, err := helpers.ListFunctionRevisions(ctx, &qc, functionID)
+			if err != nil {
+				printer.PrintWarning("revisions aren't available from the API, only revisions applied from this machine are listed")
+				printer.Logger.Debug(err.Error())
+			}
+
+			snapshots, err := helpers.ReadSnapshots(functionID)
+			if err != nil {
+				return charm.RenderError("unable to read function history", err)
+			}
+
+			history := helpers.FunctionHistory(*qFunc, revisions, snapshots)
+
+			switch {
+			case common.Quiet:
+				for _, revision := range history {
+					printer.PrintIDs(revision.Revision)
+				}
+			case common.OutputFormat == "json":
+				printer.PrintResource(utils.FormatOutput(history, common.OutputFormat))
+			default:
+				rows := make([][]string, 0, len(history))
+				for _, revision := range history {
+					name := revision.Revision
+					if revision.Current {
+						name += " (current)"
+					}
+					applied := "-"
+					if revision.AppliedAt != nil {
+						applied = revision.AppliedAt.Local().Format(time.DateTime)
+					}
+					changes := "created"
+					if revision.Changes != nil {
+						changes = revision.Changes.String()
+					}
+					rows = append(rows, []string{name, applied, changes})
+				}
+				printer.PrintResource(charm.RenderSummaryTable([]string{"Revision", "Applied", "Changes"}, rows))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&functionID, "function", "f", "", functionFlagUsage)
+	_ = cmd.MarkFlagRequired("function")
+	utils.AddQuietFlag(cmd)
+
+	return cmd
+}
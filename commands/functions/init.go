@@ -0,0 +1,378 @@
+package functions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/qernal/cli-qernal/charm"
+	"github.com/qernal/cli-qernal/commands/auth"
+	"github.com/qernal/cli-qernal/pkg/client"
+	"github.com/qernal/cli-qernal/pkg/common"
+	"github.com/qernal/cli-qernal/pkg/helpers"
+	"github.com/qernal/cli-qernal/pkg/utils"
+	openapi_chaos_client "github.com/qernal/openapi-chaos-go-client"
+	"github.com/spf13/cobra"
+)
+
+func NewInitCmd(printer *utils.Printer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "init",
+		Short: "Scaffold a function definition file",
+		Long: `Walk through the settings of one or more functions and write them to a definition file that can
+be passed to functions apply. Deployment locations are chosen from the available providers and
+secrets from the project's environment secrets.
+
+With --non-interactive the function is built from flags instead, e.g. for templates.`,
+		Example: `  qernal functions init --project <project name>
+
+  qernal functions init --project <project name> --non-interactive --name api --image nginx:latest \
+    --location qernal:Europe --secret DATABASE_URL --out - > api.yaml`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				return utils.UsageError("no arguments expected")
+			}
+			return helpers.ValidateProjectFlags(cmd)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			file, _ := cmd.Flags().GetString("out")
+			force, _ := cmd.Flags().GetBool("force")
+			if file != "-" && !force {
+				if _, err := os.Stat(file); err == nil {
+					return utils.NewError(utils.ExitConflict, fmt.Sprintf("%s already exists, use --force to overwrite it", file))
+				}
+			}
+
+			token, err := auth.GetQernalToken()
+			if err != nil {
+				return charm.RenderError("unable to retrieve qernal token, run qernal auth login if you haven't", err)
+			}
+
+			qc, err := client.New(ctx, nil, nil, token)
+			if err != nil {
+				return charm.RenderError("error creating qernal client", err)
+			}
+
+			projectID, err := helpers.GetProjectID(cmd, &qc)
+			if err != nil {
+				return err
+			}
+
+			providers, httpRes, err := qc.ProvidersAPI.ProvidersList(ctx).Execute()
+			if err != nil {
+				return helpers.FunctionRequestError(printer, "unable to list providers", httpRes, err)
+			}
+
+			secrets, err := helpers.PaginateSecrets(printer, ctx, &qc, 0, projectID)
+			if err != nil {
+				return err
+			}
+			// only environment secrets can be passed to functions
+			secrets = slices.DeleteFunc(secrets, func(secret openapi_chaos_client.SecretMetaResponse) bool {
+				return secret.Type != openapi_chaos_client.SECRETMETATYPE_ENVIRONMENT
+			})
+
+			var templates []helpers.FunctionTemplate
+			if nonInteractive, _ := cmd.Flags().GetBool("non-interactive"); nonInteractive {
+				template, err := templateFromFlags(cmd, providers.Data, secrets)
+				if err != nil {
+					return err
+				}
+				templates = append(templates, template)
+			} else {
+				templates, err = templatesFromWizard(providers.Data, secrets)
+				if err != nil {
+					return err
+				}
+			}
+
+			bodies := make([]openapi_chaos_client.FunctionBody, 0, len(templates))
+			for _, template := range templates {
+				bodies = append(bodies, template.Body(projectID))
+			}
+			content, err := helpers.ScaffoldFunctions(bodies)
+			if err != nil {
+				return utils.UsageError(err.Error())
+			}
+
+			if file == "-" {
+				printer.PrintResource(strings.TrimSuffix(content, "\n"))
+				return nil
+			}
+			if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+				return charm.RenderError("unable to write function definition", err)
+			}
+
+			if common.Quiet {
+				printer.PrintIDs(file)
+				return nil
+			}
+			printer.PrintResource(charm.SuccessStyle.Render(fmt.Sprintf("wrote %d functions to %s\nrun qernal functions apply -f %s to deploy them", len(bodies), file, file)))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("out", "O", "functions.yaml", "file to write the definitions to, - writes to stdout")
+	cmd.Flags().Bool("force", false, "overwrite the file if it exists")
+	cmd.Flags().Bool("non-interactive", false, "build the function from flags instead of prompting")
+	cmd.Flags().String("name", "", "function name")
+	cmd.Flags().String("description", "", "function description")
+	cmd.Flags().String("image", "", "container image, e.g. repo:tag")
+	cmd.Flags().String("type", "http", "function type, http or worker")
+	cmd.Flags().Int32("port", 80, "port the container listens on")
+	cmd.Flags().Int32("cpu", 128, fmt.Sprintf("cpu units, one of %s", joinSizes()))
+	cmd.Flags().Int32("memory", 128, fmt.Sprintf("memory in MB, one of %s", joinSizes()))
+	cmd.Flags().StringArray("route", nil, "route path of an http function, can be repeated (default /)")
+	cmd.Flags().StringSlice("methods", helpers.HTTPMethods, "http methods the routes accept")
+	cmd.Flags().String("scaling", "cpu", "scale on cpu or memory")
+	cmd.Flags().Int32("scale-low", 20, "usage percentage to scale down at")
+	cmd.Flags().Int32("scale-high", 70, "usage percentage to scale up at")
+	cmd.Flags().StringArray("location", nil, "provider id or name to deploy to, optionally with :continent, can be repeated")
+	cmd.Flags().Int32("min-replicas", 1, "minimum replicas per location")
+	cmd.Flags().Int32("max-replicas", 3, "maximum replicas per location")
+	cmd.Flags().StringArray("secret", nil, "environment secret to pass to the function, can be repeated")
+	utils.AddQuietFlag(cmd)
+
+	return cmd
+}
+
+func joinSizes() string {
+	sizes := make([]string, 0, len(helpers.FunctionSizes))
+	for _, size := range helpers.FunctionSizes {
+		sizes = append(sizes, strconv.Itoa(int(size)))
+	}
+	return strings.Join(sizes, ", ")
+}
+
+// templateFromFlags builds a function template from the flags of functions init
+func templateFromFlags(cmd *cobra.Command, providers []openapi_chaos_client.Provider, secrets []openapi_chaos_client.SecretMetaResponse) (helpers.FunctionTemplate, error) {
+	flags := cmd.Flags()
+	var t helpers.FunctionTemplate
+	t.Name, _ = flags.GetString("name")
+	t.Description, _ = flags.GetString("description")
+	t.Image, _ = flags.GetString("image")
+	functionType, _ := flags.GetString("type")
+	t.Type = openapi_chaos_client.FunctionType(functionType)
+	t.Port, _ = flags.GetInt32("port")
+	t.CPU, _ = flags.GetInt32("cpu")
+	t.Memory, _ = flags.GetInt32("memory")
+	t.Routes, _ = flags.GetStringArray("route")
+	t.Methods, _ = flags.GetStringSlice("methods")
+	t.ScalingType, _ = flags.GetString("scaling")
+	t.ScaleLow, _ = flags.GetInt32("scale-low")
+	t.ScaleHigh, _ = flags.GetInt32("scale-high")
+	t.MinReplicas, _ = flags.GetInt32("min-replicas")
+	t.MaxReplicas, _ = flags.GetInt32("max-replicas")
+
+	if t.Name == "" || t.Image == "" {
+		return t, utils.UsageError("--name and --image are required with --non-interactive")
+	}
+	if len(t.Routes) == 0 {
+		t.Routes = []string{"/"}
+	}
+	for i, method := range t.Methods {
+		t.Methods[i] = strings.ToUpper(method)
+	}
+
+	locations, _ := flags.GetStringArray("location")
+	if len(locations) == 0 {
+		return t, utils.UsageError("at least one --location is required with --non-interactive, see qernal providers list")
+	}
+	for _, value := range locations {
+		location, err := helpers.ParseLocation(providers, value)
+		if err != nil {
+			return t, utils.UsageError(err.Error())
+		}
+		t.Locations = append(t.Locations, location)
+	}
+
+	names, _ := flags.GetStringArray("secret")
+	for _, name := range names {
+		i := slices.IndexFunc(secrets, func(secret openapi_chaos_client.SecretMetaResponse) bool {
+			return secret.Name == name
+		})
+		if i < 0 {
+			return t, utils.NewError(utils.ExitNotFound, fmt.Sprintf("unable to find environment secret %s in project", name))
+		}
+		t.Secrets = append(t.Secrets, secrets[i])
+	}
+
+	return t, nil
+}
+
+// templatesFromWizard prompts for functions until no more are wanted
+func templatesFromWizard(providers []openapi_chaos_client.Provider, secrets []openapi_chaos_client.SecretMetaResponse) ([]helpers.FunctionTemplate, error) {
+	var templates []helpers.FunctionTemplate
+	for {
+		template, err := promptTemplate(providers, secrets, templates)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+
+		another, err := charm.Confirm("Add another function?", false)
+		if err != nil {
+			return nil, err
+		}
+		if !another {
+			return templates, nil
+		}
+	}
+}
+
+// promptTemplate asks for the settings of a single function
+func promptTemplate(providers []openapi_chaos_client.Provider, secrets []openapi_chaos_client.SecretMetaResponse, existing []helpers.FunctionTemplate) (helpers.FunctionTemplate, error) {
+	var t helpers.FunctionTemplate
+	var err error
+
+	t.Name, err = charm.Prompt("Function name:", "", func(value string) error {
+		if value == "" {
+			return errors.New("a name is required")
+		}
+		for _, other := range existing {
+			if other.Name == value {
+				return fmt.Errorf("function %s is already in this file", value)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return t, err
+	}
+
+	if t.Description, err = charm.Prompt("Description:", "", nil); err != nil {
+		return t, err
+	}
+
+	types := []string{string(openapi_chaos_client.FUNCTIONTYPE_HTTP), string(openapi_chaos_client.FUNCTIONTYPE_WORKER)}
+	picked, err := charm.Select("Function type:", types, false)
+	if err != nil {
+		return t, err
+	}
+	t.Type = openapi_chaos_client.FunctionType(types[picked[0]])
+
+	t.Image, err = charm.Prompt("Container image:", "", func(value string) error {
+		if value == "" {
+			return errors.New("an image is required")
+		}
+		return nil
+	})
+	if err != nil {
+		return t, err
+	}
+
+	if t.Port, err = promptInt("Port:", 80, 1, 65535); err != nil {
+		return t, err
+	}
+
+	cpus := make([]string, 0, len(helpers.FunctionSizes))
+	memories := make([]string, 0, len(helpers.FunctionSizes))
+	for _, size := range helpers.FunctionSizes {
+		cpus = append(cpus, fmt.Sprintf("%d units", size))
+		memories = append(memories, fmt.Sprintf("%d MB", size))
+	}
+	if picked, err = charm.Select("CPU:", cpus, false); err != nil {
+		return t, err
+	}
+	t.CPU = helpers.FunctionSizes[picked[0]]
+	if picked, err = charm.Select("Memory:", memories, false); err != nil {
+		return t, err
+	}
+	t.Memory = helpers.FunctionSizes[picked[0]]
+
+	if t.Type == openapi_chaos_client.FUNCTIONTYPE_HTTP {
+		routes, err := charm.Prompt("Route paths, comma separated:", "/", func(value string) error {
+			for _, path := range strings.Split(value, ",") {
+				if !strings.HasPrefix(strings.TrimSpace(path), "/") {
+					return fmt.Errorf("route %s must start with /", strings.TrimSpace(path))
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return t, err
+		}
+		for _, path := range strings.Split(routes, ",") {
+			t.Routes = append(t.Routes, strings.TrimSpace(path))
+		}
+
+		all := make([]int, len(helpers.HTTPMethods))
+		for i := range all {
+			all[i] = i
+		}
+		if picked, err = charm.Select("Route methods:", helpers.HTTPMethods, true, all...); err != nil {
+			return t, err
+		}
+		for _, i := range picked {
+			t.Methods = append(t.Methods, helpers.HTTPMethods[i])
+		}
+	}
+
+	scaling := []string{"cpu", "memory"}
+	if picked, err = charm.Select("Scale on:", scaling, false); err != nil {
+		return t, err
+	}
+	t.ScalingType = scaling[picked[0]]
+	if t.ScaleLow, err = promptInt("Scale down below usage %:", 20, 0, 100); err != nil {
+		return t, err
+	}
+	if t.ScaleHigh, err = promptInt("Scale up above usage %:", 70, t.ScaleLow+1, 100); err != nil {
+		return t, err
+	}
+
+	locations, labels := helpers.ProviderLocations(providers)
+	for {
+		if picked, err = charm.Select("Deploy to:", labels, true); err != nil {
+			return t, err
+		}
+		if len(picked) > 0 {
+			break
+		}
+	}
+	for _, i := range picked {
+		t.Locations = append(t.Locations, locations[i])
+	}
+	if t.MinReplicas, err = promptInt("Minimum replicas per location:", 1, 0, 1<<31-1); err != nil {
+		return t, err
+	}
+	if t.MaxReplicas, err = promptInt("Maximum replicas per location:", max(t.MinReplicas, 3), max(t.MinReplicas, 1), 1<<31-1); err != nil {
+		return t, err
+	}
+
+	if len(secrets) > 0 {
+		names := make([]string, 0, len(secrets))
+		for _, secret := range secrets {
+			names = append(names, secret.Name)
+		}
+		if picked, err = charm.Select("Secrets to pass as environment variables:", names, true); err != nil {
+			return t, err
+		}
+		for _, i := range picked {
+			t.Secrets = append(t.Secrets, secrets[i])
+		}
+	}
+
+	return t, nil
+}
+
+// promptInt asks for a number between low and high
+func promptInt(title string, defaultValue int32, low int32, high int32) (int32, error) {
+	value, err := charm.Prompt(title, strconv.Itoa(int(defaultValue)), func(value string) error {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < int(low) || n > int(high) {
+			return fmt.Errorf("enter a number between %d and %d", low, high)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	n, _ := strconv.Atoi(value)
+	return int32(n), nil
+}
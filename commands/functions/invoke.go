@@ -0,0 +1,196 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/qernal/cli-qernal/charm"
+	"github.com/qernal/cli-qernal/commands/auth"
+	"github.com/qernal/cli-qernal/pkg/client"
+	"github.com/qernal/cli-qernal/pkg/common"
+	"github.com/qernal/cli-qernal/pkg/helpers"
+	"github.com/qernal/cli-qernal/pkg/utils"
+	openapi_chaos_client "github.com/qernal/openapi-chaos-go-client"
+	"github.com/spf13/cobra"
+)
+
+func NewInvokeCmd(printer *utils.Printer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "invoke",
+		Short: "Send an HTTP request to a function",
+		Long: `Send an HTTP request to a function and print the response status, headers, body and how long it
+took. The request goes to the project's default *.qrnl.app host, or to --host. --url sends it to
+another address instead, e.g. a local server, and doesn't need --function.`,
+		Example: `  qernal functions invoke --project <project name> --function api --path /health
+  qernal functions invoke --project <project name> --function api --path /orders -X POST -d @body.json -H "Content-Type: application/json"
+  qernal functions invoke --url http://localhost:8080 --path /health`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				return utils.UsageError("no arguments expected")
+			}
+
+			ctx := context.Background()
+			request, _, err := functionRequest(ctx, cmd, printer)
+			if err != nil {
+				return err
+			}
+
+			timeout, _ := cmd.Flags().GetDuration("timeout")
+			res, err := helpers.Invoke(ctx, &http.Client{Timeout: timeout}, request)
+			if err != nil {
+				return charm.RenderError(fmt.Sprintf("unable to send %s %s", request.Method, request.URL), err)
+			}
+
+			switch {
+			case common.Quiet:
+				printer.PrintResource(res.Body)
+			case common.OutputFormat == "json":
+				printer.PrintResource(utils.FormatOutput(res, common.OutputFormat))
+			default:
+				printer.PrintResource(renderInvokeResponse(res))
+			}
+
+			if fail, _ := cmd.Flags().GetBool("fail"); fail && res.StatusCode >= 400 {
+				return utils.NewError(utils.ExitError, fmt.Sprintf("%s %s returned %s", request.Method, request.URL, res.Status))
+			}
+			return nil
+		},
+	}
+
+	addRequestFlags(cmd)
+	cmd.Flags().Duration("timeout", 30*time.Second, "how long to wait for the response")
+	cmd.Flags().Bool("fail", false, "exit with code 1 when the response status is 400 or above")
+	cmd.Flags().BoolVarP(&common.Quiet, "quiet", "q", false, "only print the response body")
+
+	return cmd
+}
+
+// addRequestFlags adds the flags describing an HTTP request to a function
+func addRequestFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP("function", "f", "", functionFlagUsage)
+	cmd.Flags().String("path", "", "path to request, defaults to the first route of the function or /")
+	cmd.Flags().StringP("request", "X", "", "http method, defaults to GET, or POST with --data")
+	cmd.Flags().StringP("data", "d", "", "request body, @file reads a file and @- reads stdin")
+	cmd.Flags().StringArrayP("header", "H", nil, `request header as "Name: value", can be repeated`)
+	cmd.Flags().String("host", "", "host of the function's project to send the request to, defaults to its *.qrnl.app host")
+	cmd.Flags().String("url", "", "base url to send the request to instead of a host of the function, e.g. http://localhost:8080")
+	cmd.MarkFlagsMutuallyExclusive("host", "url")
+}
+
+// functionRequest builds the request described by the request flags. Unless --url is set the
+// function is looked up to find its host. The function is nil when only --url is given.
+func functionRequest(ctx context.Context, cmd *cobra.Command, printer *utils.Printer) (helpers.InvokeRequest, *openapi_chaos_client.Function, error) {
+	name, _ := cmd.Flags().GetString("function")
+	baseURL, _ := cmd.Flags().GetString("url")
+	if name == "" && baseURL == "" {
+		return helpers.InvokeRequest{}, nil, utils.UsageError("--function or --url is required")
+	}
+
+	var function *openapi_chaos_client.Function
+	if name != "" {
+		token, err := auth.GetQernalToken()
+		if err != nil {
+			return helpers.InvokeRequest{}, nil, charm.RenderError("unable to retrieve qernal token, run qernal auth login if you haven't", err)
+		}
+
+		qc, err := client.New(ctx, nil, nil, token)
+		if err != nil {
+			return helpers.InvokeRequest{}, nil, charm.RenderError("error creating qernal client", err)
+		}
+
+		functionID, err := functionFlagID(cmd, &qc, printer)
+		if err != nil {
+			return helpers.InvokeRequest{}, nil, err
+		}
+
+		var httpRes *http.Response
+		function, httpRes, err = qc.FunctionsAPI.FunctionsGet(ctx, functionID).Execute()
+		if err != nil {
+			return helpers.InvokeRequest{}, nil, helpers.FunctionRequestError(printer, "unable to find function", httpRes, err)
+		}
+
+		if baseURL == "" {
+			hostName, _ := cmd.Flags().GetString("host")
+			if baseURL, err = helpers.FunctionHost(ctx, &qc, function.ProjectId, hostName); err != nil {
+				return helpers.InvokeRequest{}, nil, charm.RenderError(fmt.Sprintf("unable to find a host for function %s", function.Name), err)
+			}
+		}
+	}
+
+	headerValues, _ := cmd.Flags().GetStringArray("header")
+	header, err := helpers.ParseHeaders(headerValues)
+	if err != nil {
+		return helpers.InvokeRequest{}, nil, utils.UsageError(err.Error())
+	}
+	request := helpers.InvokeRequest{Header: header}
+
+	if cmd.Flags().Changed("data") {
+		data, _ := cmd.Flags().GetString("data")
+		if request.Body, err = helpers.ReadRequestData(data, cmd.InOrStdin()); err != nil {
+			return helpers.InvokeRequest{}, nil, charm.RenderError("unable to read --data", err)
+		}
+	}
+
+	method, _ := cmd.Flags().GetString("request")
+	switch {
+	case method != "":
+		request.Method = strings.ToUpper(method)
+	case request.Body != nil:
+		request.Method = http.MethodPost
+	default:
+		request.Method = http.MethodGet
+	}
+
+	path, _ := cmd.Flags().GetString("path")
+	if path == "" {
+		path = "/"
+		if function != nil {
+			path = helpers.DefaultRoutePath(function.Routes)
+		}
+	}
+	if request.URL, err = helpers.FunctionURL(baseURL, path); err != nil {
+		return helpers.InvokeRequest{}, nil, utils.UsageError(err.Error())
+	}
+
+	if function != nil {
+		if function.Type != openapi_chaos_client.FUNCTIONTYPE_HTTP {
+			printer.PrintWarning(fmt.Sprintf("function %s is a %s function, it doesn't serve http requests", function.Name, function.Type))
+		} else if _, ok := helpers.MatchRoute(function.Routes, request.Method, path); !ok {
+			printer.PrintWarning(fmt.Sprintf("no route of function %s accepts %s %s, the request may not reach it", function.Name, request.Method, path))
+		}
+	}
+	return request, function, nil
+}
+
+// renderInvokeResponse renders a response as its status line and timing, headers and body
+func renderInvokeResponse(res *helpers.InvokeResponse) string {
+	status := charm.SuccessStyle
+	if res.StatusCode >= 400 {
+		status = charm.ErrorStyle
+	}
+
+	var b strings.Builder
+	b.WriteString(status.Render(fmt.Sprintf("%s %s", res.Proto, res.Status)))
+	fmt.Fprintf(&b, " in %s\n", res.Duration)
+
+	names := make([]string, 0, len(res.Header))
+	for name := range res.Header {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		for _, value := range res.Header[name] {
+			fmt.Fprintf(&b, "%s: %s\n", name, value)
+		}
+	}
+
+	if res.Body != "" {
+		b.WriteString("\n")
+		b.WriteString(res.Body)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
@@ -0,0 +1,50 @@
+package functions
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/qernal/cli-qernal/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvokeURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", r.Header.Get("Content-Type"))
+		_, _ = w.Write([]byte(r.Method + " " + string(body)))
+	}))
+	defer server.Close()
+
+	printer := utils.NewPrinter()
+	var out bytes.Buffer
+	printer.SetOut(&out)
+
+	cmd := NewInvokeCmd(printer)
+	cmd.SetIn(strings.NewReader(`{"id":1}`))
+	cmd.SetArgs([]string{"--url", server.URL, "--path", "/orders", "-d", "@-", "-H", "Content-Type: application/json"})
+	require.NoError(t, cmd.Execute())
+
+	output := out.String()
+	assert.Contains(t, output, "200 OK")
+	assert.Contains(t, output, "Content-Type: application/json")
+	assert.True(t, strings.HasSuffix(output, "\nPOST {\"id\":1}\n"), output)
+
+	cmd = NewInvokeCmd(printer)
+	cmd.SetArgs([]string{"--url", server.URL, "--path", "/missing", "--fail"})
+	err := cmd.Execute()
+	assert.Equal(t, utils.ExitError, utils.ExitCode(err))
+
+	cmd = NewInvokeCmd(printer)
+	cmd.SetArgs([]string{"--path", "/health"})
+	assert.Equal(t, utils.ExitUsage, utils.ExitCode(cmd.Execute()))
+}
@@ -16,11 +16,19 @@ func NewListCmd(printer *utils.Printer) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "list",
 		Aliases: []string{"ls"},
-		Example: "qernal func list --project <project name> ",
+		Example: `  qernal func list --project <project name>
+
+  # Export every function in the project as a definition file
+  qernal func list --project <project name> --export > functions.yaml`,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			return helpers.ValidateProjectFlags(cmd)
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			export, err := exportRequested(cmd)
+			if err != nil {
+				return err
+			}
+
 			ctx := context.Background()
 
 			token, err := auth.GetQernalToken()
@@ -39,22 +47,38 @@ func NewListCmd(printer *utils.Printer) *cobra.Command {
 			}
 			maxResults, _ := cmd.Flags().GetInt32("max")
 
-			functions, err := helpers.PaginateFunctions(printer, ctx, &qc, maxResults, projectID)
-			if err != nil {
-				return charm.RenderError("unable to list function", err)
-			}
+			return printer.Watch(func() error {
+				functions, err := helpers.PaginateFunctions(printer, ctx, &qc, maxResults, projectID)
+				if err != nil {
+					return charm.RenderError("unable to list function", err)
+				}
 
-			if common.OutputFormat == "json" {
-				printer.PrintResource(utils.FormatOutput(functions, common.OutputFormat))
-				return nil
-			}
+				if common.Quiet {
+					for _, function := range functions {
+						printer.PrintIDs(function.Id)
+					}
+					return nil
+				}
 
-			table := charm.RenderFuncTable(functions)
-			printer.PrintResource(table)
+				if export {
+					return printExport(printer, functions)
+				}
 
-			return nil
+				if common.OutputFormat == "json" {
+					printer.PrintResource(utils.FormatOutput(functions, common.OutputFormat))
+					return nil
+				}
+
+				table := charm.RenderFuncTable(functions)
+				printer.PrintResource(table)
+
+				return nil
+			})
 		},
 	}
+	addExportFlag(cmd)
+	utils.AddQuietFlag(cmd)
+	utils.AddWatchFlags(cmd)
 
 	return cmd
 }
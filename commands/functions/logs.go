@@ -17,8 +17,11 @@ import (
 
 func NewLogsCmd(printer *utils.Printer) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "logs",
-		Example: "qernal func logs --project-id <project name> --function-id <function name>",
+		Use: "logs",
+		Example: `  qernal func logs --project <project name> --function <function name>
+
+  # Tail logs, polling every 10 seconds
+  qernal func logs --project-id <project id> --function <function id> --watch --interval 10s`,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			return helpers.ValidateProjectFlags(cmd)
 		},
@@ -40,13 +43,20 @@ func NewLogsCmd(printer *utils.Printer) *cobra.Command {
 				return err
 			}
 
-			functionID, _ := cmd.Flags().GetString("function")
-			watch := cmd.Flags().Changed("watch")
+			functionID, err := functionFlagID(cmd, &qc, printer)
+			if err != nil {
+				return err
+			}
 
-			// TODO: if watch and json provided, then error
+			// if we're watching logs, tail them until interrupted
+			if common.Watch {
+				if common.OutputFormat == "json" {
+					return utils.UsageError("--watch can't be combined with json output")
+				}
+				if common.WatchInterval <= 0 {
+					return utils.UsageError("--interval must be greater than zero")
+				}
 
-			// if we're watching logs, loop forever
-			if watch {
 				lastHashes := [][]byte{}
 				lastWatchDate := ""
 				writeLog := true
@@ -100,8 +110,7 @@ func NewLogsCmd(printer *utils.Printer) *cobra.Command {
 					// overwrite last hashes
 					lastHashes = currentHashes
 
-					// TODO: allow this to be configurable via flag
-					time.Sleep(5 * time.Second)
+					time.Sleep(common.WatchInterval)
 				}
 			}
 
@@ -113,18 +122,19 @@ func NewLogsCmd(printer *utils.Printer) *cobra.Command {
 
 			// show logs as json
 			if common.OutputFormat == "json" {
-				printer.PrintResource(utils.FormatOutput(logs, common.OutputFormat))
+				printer.PrintPaged(utils.FormatOutput(logs, common.OutputFormat))
 				return nil
 			}
 
 			// show logs (non-watch)
-			printer.PrintResource(formatLogs(logs.Data))
+			printer.PrintPaged(formatLogs(logs.Data))
 			return nil
 		},
 	}
 
-	cmd.Flags().StringVarP(&functionID, "function", "f", "", "function id")
-	cmd.Flags().BoolVarP(&watch, "watch", "", false, "watch logs")
+	cmd.Flags().StringVarP(&functionID, "function", "f", "", functionFlagUsage)
+	cmd.Flags().BoolVarP(&common.Watch, "watch", "w", false, "keep printing new log lines until ctrl+c is pressed")
+	cmd.Flags().DurationVar(&common.WatchInterval, "interval", 5*time.Second, "how often --watch checks for new log lines")
 
 	_ = cmd.MarkFlagRequired("function")
 
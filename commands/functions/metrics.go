@@ -3,7 +3,6 @@ package functions
 import (
 	"context"
 	"errors"
-	"fmt"
 	"log/slog"
 	"time"
 
@@ -22,7 +21,7 @@ import (
 func NewMetricsCmd(printer *utils.Printer) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "metrics",
-		Example: "qernal func metrics --project-id <project name> --function-id <function name>",
+		Example: "qernal func metrics --project <project name> --function <function name>",
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			return helpers.ValidateProjectFlags(cmd)
 		},
@@ -44,137 +43,150 @@ func NewMetricsCmd(printer *utils.Printer) *cobra.Command {
 				return err
 			}
 
-			functionID, _ := cmd.Flags().GetString("function")
-			// TODO: add watch flag for graph refreshes
-			currentTime := time.Now().Format(time.RFC3339)
-			pastTime := time.Now().Add(-15 * time.Minute).Format(time.RFC3339)
-
-			// show http requests
-			metricResp, httpRes, err := qc.MetricsAPI.MetricsAggregationsList(context.Background(), "httprequests").
-				FProject(projectID).
-				FFunction(functionID).
-				FHistogramInterval(60).
-				FTimestamps(openapi_chaos_client.LogsListFTimestampsParameter{
-					After:  &pastTime,
-					Before: &currentTime,
-				}).
-				Execute()
+			functionID, err := functionFlagID(cmd, &qc, printer)
 			if err != nil {
-				resData, _ := client.ParseResponseData(httpRes)
-				if data, ok := resData.(map[string]interface{}); ok {
-					if innerData, ok := data["data"].(map[string]interface{}); ok {
-						if nameErr, ok := innerData["name"].(string); ok {
+				return err
+			}
 
-							return printer.RenderError("unable to find function", errors.New(nameErr))
+			return printer.Watch(func() error {
+				currentTime := time.Now().Format(time.RFC3339)
+				pastTime := time.Now().Add(-15 * time.Minute).Format(time.RFC3339)
+
+				// show http requests
+				metricResp, httpRes, err := qc.MetricsAPI.MetricsAggregationsList(context.Background(), "httprequests").
+					FProject(projectID).
+					FFunction(functionID).
+					FHistogramInterval(60).
+					FTimestamps(openapi_chaos_client.LogsListFTimestampsParameter{
+						After:  &pastTime,
+						Before: &currentTime,
+					}).
+					Execute()
+				if err != nil {
+					resData, _ := client.ParseResponseData(httpRes)
+					if data, ok := resData.(map[string]interface{}); ok {
+						if innerData, ok := data["data"].(map[string]interface{}); ok {
+							if nameErr, ok := innerData["name"].(string); ok {
+
+								return printer.RenderError("unable to find function", client.NewResponseError(nameErr, err))
+							}
 						}
 					}
+					printer.Logger.Debug("Metrics collection failed ",
+						slog.String("error", err.Error()),
+						slog.Any("response", httpRes))
+					return charm.RenderError("unable to find function", err)
 				}
-				printer.Logger.Debug("Metrics collection failed ",
-					slog.String("error", err.Error()),
-					slog.Any("response", httpRes))
-				return charm.RenderError("unable to find function", err)
-			}
 
-			if len(metricResp.MetricHttpAggregation.HttpCodes.Buckets) <= 0 {
-				return errors.New(charm.RenderWarning("Function metrics are currently unavailable, make a few requests and try again"))
-			}
+				if len(metricResp.MetricHttpAggregation.HttpCodes.Buckets) <= 0 {
+					return errors.New(charm.RenderWarning("Function metrics are currently unavailable, make a few requests and try again"))
+				}
 
-			for _, r := range metricResp.MetricHttpAggregation.HttpCodes.Buckets {
-				fmt.Println(*r.Key)
-				HTTPGraph(*r.Histogram)
-			}
+				for _, r := range metricResp.MetricHttpAggregation.HttpCodes.Buckets {
+					printer.PrintResource(*r.Key)
+					printer.PrintResource(HTTPGraph(*r.Histogram))
+				}
 
-			// show resource stats
-			metricResp, httpRes, err = qc.MetricsAPI.MetricsAggregationsList(context.Background(), "resourcestats").
-				FProject(projectID).
-				FFunction(functionID).
-				FHistogramInterval(60).
-				FTimestamps(openapi_chaos_client.LogsListFTimestampsParameter{
-					After:  &pastTime,
-					Before: &currentTime,
-				}).
-				Execute()
-			if err != nil {
-				resData, _ := client.ParseResponseData(httpRes)
-				if data, ok := resData.(map[string]interface{}); ok {
-					if innerData, ok := data["data"].(map[string]interface{}); ok {
-						if nameErr, ok := innerData["name"].(string); ok {
-							printer.Logger.Debug("MEtrics collection failed ",
-								slog.String("error", err.Error()),
-								slog.Any("response", nameErr))
-							return printer.RenderError("unable to find function", errors.New(nameErr))
+				// show resource stats
+				metricResp, httpRes, err = qc.MetricsAPI.MetricsAggregationsList(context.Background(), "resourcestats").
+					FProject(projectID).
+					FFunction(functionID).
+					FHistogramInterval(60).
+					FTimestamps(openapi_chaos_client.LogsListFTimestampsParameter{
+						After:  &pastTime,
+						Before: &currentTime,
+					}).
+					Execute()
+				if err != nil {
+					resData, _ := client.ParseResponseData(httpRes)
+					if data, ok := resData.(map[string]interface{}); ok {
+						if innerData, ok := data["data"].(map[string]interface{}); ok {
+							if nameErr, ok := innerData["name"].(string); ok {
+								printer.Logger.Debug("MEtrics collection failed ",
+									slog.String("error", err.Error()),
+									slog.Any("response", nameErr))
+								return printer.RenderError("unable to find function", client.NewResponseError(nameErr, err))
+							}
 						}
 					}
+					printer.Logger.Debug("MEtrics collection failed ",
+						slog.String("error", err.Error()),
+						slog.Any("response", httpRes))
+					return charm.RenderError("unable to find function", err)
 				}
-				printer.Logger.Debug("MEtrics collection failed ",
-					slog.String("error", err.Error()),
-					slog.Any("response", httpRes))
-				return charm.RenderError("unable to find function", err)
-			}
 
-			// TODO: format header
-			fmt.Println("Resource Stats")
+				// TODO: format header
+				printer.PrintResource("Resource Stats")
 
-			networkData := map[string]openapi_chaos_client.MetricResourceAggregationResourcesBucketsInner{}
-			memoryData := map[string]openapi_chaos_client.MetricResourceAggregationResourcesBucketsInner{}
+				networkData := map[string]openapi_chaos_client.MetricResourceAggregationResourcesBucketsInner{}
+				memoryData := map[string]openapi_chaos_client.MetricResourceAggregationResourcesBucketsInner{}
 
-			res := metricResp.MetricResourceAggregation.Resources.Buckets
-			for _, r := range res {
-				if *r.Key == "cpu-usage" {
-					CPUGraph(r)
-				}
+				res := metricResp.MetricResourceAggregation.Resources.Buckets
+				for _, r := range res {
+					if *r.Key == "cpu-usage" {
+						printer.PrintResource(CPUGraph(r))
+					}
 
-				if *r.Key == "network-tx" {
-					networkData["tx"] = r
-				}
+					if *r.Key == "network-tx" {
+						networkData["tx"] = r
+					}
 
-				if *r.Key == "network-rx" {
-					networkData["rx"] = r
-				}
+					if *r.Key == "network-rx" {
+						networkData["rx"] = r
+					}
 
-				if *r.Key == "memory-usage" {
-					memoryData["usage"] = r
-				}
+					if *r.Key == "memory-usage" {
+						memoryData["usage"] = r
+					}
 
-				if *r.Key == "memory-available" {
-					memoryData["capacity"] = r
+					if *r.Key == "memory-available" {
+						memoryData["capacity"] = r
+					}
 				}
-			}
 
-			NetworkGraph(networkData["tx"], networkData["rx"])
-			MemoryGraph(memoryData["usage"], memoryData["capacity"])
+				printer.PrintResource(NetworkGraph(networkData["tx"], networkData["rx"]))
+				printer.PrintResource(MemoryGraph(memoryData["usage"], memoryData["capacity"]))
 
-			// TODO: if watch and json provided, then error
+				if err != nil {
+					return charm.RenderError("unable to retrieve metrics, request failed with:", err)
+				}
 
-			if err != nil {
-				return charm.RenderError("unable to retrieve metrics, request failed with:", err)
-			}
+				// TODO: show both metric requests as json
+				if common.OutputFormat == "json" {
+					return nil
+				}
 
-			// TODO: show both metric requests as json
-			if common.OutputFormat == "json" {
 				return nil
-			}
-
-			return nil
+			})
 		},
 	}
 
-	cmd.Flags().StringVarP(&functionID, "function", "f", "", "function id")
-	cmd.Flags().BoolVarP(&watch, "watch", "", false, "watch logs")
+	cmd.Flags().StringVarP(&functionID, "function", "f", "", functionFlagUsage)
 
 	_ = cmd.MarkFlagRequired("function")
+	utils.AddWatchFlags(cmd)
 
 	return cmd
 }
 
-func CPUGraph(res openapi_chaos_client.MetricResourceAggregationResourcesBucketsInner) {
+// newGraph returns an empty time series chart styled with the current theme
+func newGraph() timeserieslinechart.Model {
+	theme := charm.CurrentTheme()
 	tslc := timeserieslinechart.New(41, 10)
 	tslc.XLabelFormatter = timeserieslinechart.HourTimeLabelFormatter()
+	tslc.AxisStyle = lipgloss.NewStyle().Foreground(theme.Color(charm.ColorMuted))
+	tslc.LabelStyle = lipgloss.NewStyle().Foreground(theme.Color(charm.ColorMuted))
+	tslc.SetStyle(lipgloss.NewStyle().Foreground(theme.Color(charm.ColorChartPrimary)))
+	return tslc
+}
+
+func CPUGraph(res openapi_chaos_client.MetricResourceAggregationResourcesBucketsInner) string {
+	tslc := newGraph()
 
 	for _, v := range res.Histogram.Buckets {
 		date, err := time.Parse(time.RFC3339, *v.KeyAsString)
 		if err != nil {
-			fmt.Println("Error parsing date:", err)
+			slog.Debug("error parsing metric date", slog.String("error", err.Error()))
 			continue
 		}
 
@@ -186,20 +198,19 @@ func CPUGraph(res openapi_chaos_client.MetricResourceAggregationResourcesBuckets
 
 	tslc.DrawBraille()
 
-	fmt.Println(tslc.View())
+	return tslc.View()
 }
 
 // network-tx
 // network-rx
-func NetworkGraph(tx openapi_chaos_client.MetricResourceAggregationResourcesBucketsInner, rx openapi_chaos_client.MetricResourceAggregationResourcesBucketsInner) {
-	tslc := timeserieslinechart.New(41, 10)
-	tslc.XLabelFormatter = timeserieslinechart.HourTimeLabelFormatter()
+func NetworkGraph(tx openapi_chaos_client.MetricResourceAggregationResourcesBucketsInner, rx openapi_chaos_client.MetricResourceAggregationResourcesBucketsInner) string {
+	tslc := newGraph()
 
 	// tx bucket
 	for _, t := range tx.Histogram.Buckets {
 		date, err := time.Parse(time.RFC3339, *t.KeyAsString)
 		if err != nil {
-			fmt.Println("Error parsing date:", err)
+			slog.Debug("error parsing metric date", slog.String("error", err.Error()))
 			continue
 		}
 
@@ -211,14 +222,14 @@ func NetworkGraph(tx openapi_chaos_client.MetricResourceAggregationResourcesBuck
 
 	tslc.SetStyle(
 		lipgloss.NewStyle().
-			Foreground(lipgloss.Color("10")), // green
+			Foreground(charm.CurrentTheme().Color(charm.ColorChartPrimary)),
 	)
 
 	// rx bucket
 	for _, r := range rx.Histogram.Buckets {
 		date, err := time.Parse(time.RFC3339, *r.KeyAsString)
 		if err != nil {
-			fmt.Println("Error parsing date:", err)
+			slog.Debug("error parsing metric date", slog.String("error", err.Error()))
 			continue
 		}
 
@@ -230,25 +241,24 @@ func NetworkGraph(tx openapi_chaos_client.MetricResourceAggregationResourcesBuck
 
 	tslc.SetDataSetStyle("rx",
 		lipgloss.NewStyle().
-			Foreground(lipgloss.Color("21")), // green
+			Foreground(charm.CurrentTheme().Color(charm.ColorChartSecondary)),
 	)
 
 	// chart
 	tslc.DrawBrailleAll()
-	fmt.Println(tslc.View())
+	return tslc.View()
 }
 
 // memory-usage
 // memory-available
-func MemoryGraph(usage openapi_chaos_client.MetricResourceAggregationResourcesBucketsInner, capacity openapi_chaos_client.MetricResourceAggregationResourcesBucketsInner) {
-	tslc := timeserieslinechart.New(41, 10)
-	tslc.XLabelFormatter = timeserieslinechart.HourTimeLabelFormatter()
+func MemoryGraph(usage openapi_chaos_client.MetricResourceAggregationResourcesBucketsInner, capacity openapi_chaos_client.MetricResourceAggregationResourcesBucketsInner) string {
+	tslc := newGraph()
 
 	// available bucket
 	for _, t := range capacity.Histogram.Buckets {
 		date, err := time.Parse(time.RFC3339, *t.KeyAsString)
 		if err != nil {
-			fmt.Println("Error parsing date:", err)
+			slog.Debug("error parsing metric date", slog.String("error", err.Error()))
 			continue
 		}
 
@@ -260,14 +270,14 @@ func MemoryGraph(usage openapi_chaos_client.MetricResourceAggregationResourcesBu
 
 	tslc.SetStyle(
 		lipgloss.NewStyle().
-			Foreground(lipgloss.Color("10")), // green
+			Foreground(charm.CurrentTheme().Color(charm.ColorChartPrimary)),
 	)
 
 	// usage bucket
 	for _, r := range usage.Histogram.Buckets {
 		date, err := time.Parse(time.RFC3339, *r.KeyAsString)
 		if err != nil {
-			fmt.Println("Error parsing date:", err)
+			slog.Debug("error parsing metric date", slog.String("error", err.Error()))
 			continue
 		}
 
@@ -279,23 +289,22 @@ func MemoryGraph(usage openapi_chaos_client.MetricResourceAggregationResourcesBu
 
 	tslc.SetDataSetStyle("usage",
 		lipgloss.NewStyle().
-			Foreground(lipgloss.Color("21")), // green
+			Foreground(charm.CurrentTheme().Color(charm.ColorChartSecondary)),
 	)
 
 	// chart
 	tslc.DrawBrailleAll()
-	fmt.Println(tslc.View())
+	return tslc.View()
 }
 
 // http requests
-func HTTPGraph(res openapi_chaos_client.MetricHttpAggregationHttpCodesBucketsInnerHistogram) {
-	tslc := timeserieslinechart.New(41, 10)
-	tslc.XLabelFormatter = timeserieslinechart.HourTimeLabelFormatter()
+func HTTPGraph(res openapi_chaos_client.MetricHttpAggregationHttpCodesBucketsInnerHistogram) string {
+	tslc := newGraph()
 
 	for _, v := range res.Buckets {
 		date, err := time.Parse(time.RFC3339, *v.KeyAsString)
 		if err != nil {
-			fmt.Println("Error parsing date:", err)
+			slog.Debug("error parsing metric date", slog.String("error", err.Error()))
 			continue
 		}
 
@@ -306,5 +315,5 @@ func HTTPGraph(res openapi_chaos_client.MetricHttpAggregationHttpCodesBucketsInn
 	}
 
 	tslc.DrawBraille()
-	fmt.Println(tslc.View())
+	return tslc.View()
 }
@@ -0,0 +1,196 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/x/term"
+	"github.com/qernal/cli-qernal/charm"
+	"github.com/qernal/cli-qernal/commands/auth"
+	"github.com/qernal/cli-qernal/pkg/client"
+	"github.com/qernal/cli-qernal/pkg/common"
+	"github.com/qernal/cli-qernal/pkg/helpers"
+	"github.com/qernal/cli-qernal/pkg/utils"
+	openapi_chaos_client "github.com/qernal/openapi-chaos-go-client"
+	"github.com/spf13/cobra"
+)
+
+func NewPromoteCmd(printer *utils.Printer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "promote",
+		Short: "Copy a live function from one project to another",
+		Long: `Copy the live spec of a function to another project, e.g. from staging to production. Secret
+references are remapped to the current revision of the secret with the same name in the target
+project, the promotion fails if any of them is missing there. The function is created in the target
+project, or updated if one with the same name exists. A diff of the change is shown and confirmed
+before anything is changed, pass --yes to skip the confirmation.`,
+		Example: "qernal functions promote --from-project staging --to-project prod --function api",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				return utils.UsageError("no arguments expected")
+			}
+
+			ctx := context.Background()
+			token, err := auth.GetQernalToken()
+			if err != nil {
+				return charm.RenderError("unable to retrieve qernal token, run qernal auth login if you haven't", err)
+			}
+
+			qc, err := client.New(ctx, nil, nil, token)
+			if err != nil {
+				return charm.RenderError("error creating qernal client", err)
+			}
+
+			from, _ := cmd.Flags().GetString("from-project")
+			to, _ := cmd.Flags().GetString("to-project")
+			fromID, err := helpers.ResolveProject(&qc, from)
+			if err != nil {
+				return err
+			}
+			toID, err := helpers.ResolveProject(&qc, to)
+			if err != nil {
+				return err
+			}
+			if fromID == toID {
+				return utils.UsageError("--from-project and --to-project are the same project")
+			}
+
+			function, err := sourceFunction(ctx, cmd, &qc, printer, fromID)
+			if err != nil {
+				return err
+			}
+
+			targetFunctions, err := helpers.PaginateFunctions(printer, ctx, &qc, 0, toID)
+			if err != nil {
+				return err
+			}
+			var existing *openapi_chaos_client.Function
+			if match, err := helpers.FindFunctionByName(targetFunctions, function.Name); err == nil {
+				existing = &match
+			} else if utils.ExitCode(err) != utils.ExitNotFound {
+				return err
+			}
+
+			secrets, err := helpers.ProjectSecrets(ctx, &qc, printer, []string{toID})
+			if err != nil {
+				return err
+			}
+			body, problems := helpers.PromoteFunction(*function, toID, secrets[toID])
+			if len(problems) > 0 {
+				messages := make([]string, 0, len(problems))
+				for _, problem := range problems {
+					messages = append(messages, problem.Error())
+				}
+				return utils.NewError(utils.ExitNotFound, fmt.Sprintf("unable to promote function %s, %d secret references don't resolve in project %s\n%s",
+					function.Name, len(problems), to, strings.Join(messages, "\n")))
+			}
+
+			current := ""
+			if existing != nil {
+				current, err = helpers.FunctionBodyYAML(helpers.FunctionToBody(*existing))
+				if err != nil {
+					return charm.RenderError("unable to compare functions", err)
+				}
+			}
+			desired, err := helpers.FunctionBodyYAML(body)
+			if err != nil {
+				return charm.RenderError("unable to compare functions", err)
+			}
+			diff := helpers.UnifiedDiff(to+"/"+function.Name, from+"/"+function.Name, current, desired)
+
+			if diff == "" {
+				switch {
+				case common.Quiet:
+					printer.PrintIDs(existing.Id)
+				case common.OutputFormat == "json":
+					printer.PrintResource(utils.FormatOutput(existing, common.OutputFormat))
+				default:
+					printer.PrintResource(charm.SuccessStyle.Render(fmt.Sprintf("function %s in project %s already matches project %s", function.Name, to, from)))
+				}
+				return nil
+			}
+
+			if helpers.DryRun(cmd) {
+				if existing == nil {
+					return helpers.PrintDryRun(printer, helpers.CreateFunctionRequest(body))
+				}
+				return helpers.PrintDryRun(printer, helpers.UpdateFunctionRequest(existing.Id, existing.Revision, body))
+			}
+
+			if !common.Quiet && common.OutputFormat != "json" {
+				printer.PrintResource(charm.RenderDiff(diff))
+			}
+
+			verb := "create"
+			if existing != nil {
+				verb = "update"
+			}
+			if yes, _ := cmd.Flags().GetBool("yes"); !yes {
+				if !term.IsTerminal(os.Stdin.Fd()) {
+					return utils.UsageError("unable to confirm the promotion without a terminal, pass --yes to promote anyway")
+				}
+				confirmed, err := charm.Confirm(fmt.Sprintf("%s function %s in project %s?", verb, function.Name, to), false)
+				if err != nil {
+					return charm.RenderError("unable to confirm the promotion", err)
+				}
+				if !confirmed {
+					printer.PrintWarning("promotion cancelled, nothing was changed")
+					return nil
+				}
+			}
+
+			var promoted *openapi_chaos_client.Function
+			if existing == nil {
+				promoted, err = helpers.CreateFunction(ctx, &qc, printer, body)
+			} else {
+				promoted, err = helpers.UpdateFunction(ctx, &qc, printer, existing.Id, existing.Revision, body)
+			}
+			if err != nil {
+				return err
+			}
+
+			switch {
+			case common.Quiet:
+				printer.PrintIDs(promoted.Id)
+			case common.OutputFormat == "json":
+				printer.PrintResource(utils.FormatOutput(promoted, common.OutputFormat))
+			default:
+				printer.PrintResource(charm.SuccessStyle.Render(fmt.Sprintf("promoted function %s from project %s to %s, %sd at revision %s", promoted.Name, from, to, verb, promoted.Revision)))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("function", "f", "", "function id or name in --from-project")
+	cmd.Flags().String("from-project", "", "id or name of the project to promote the function from")
+	cmd.Flags().String("to-project", "", "id or name of the project to promote the function to")
+	cmd.Flags().BoolP("yes", "y", false, "promote without asking for confirmation")
+	helpers.AddDryRunFlag(cmd)
+	_ = cmd.MarkFlagRequired("function")
+	_ = cmd.MarkFlagRequired("from-project")
+	_ = cmd.MarkFlagRequired("to-project")
+	utils.AddQuietFlag(cmd)
+
+	return cmd
+}
+
+// sourceFunction fetches the live function given with --function, names are looked up in projectID
+// and ids must belong to it
+func sourceFunction(ctx context.Context, cmd *cobra.Command, qc *client.QernalAPIClient, printer *utils.Printer, projectID string) (*openapi_chaos_client.Function, error) {
+	name, _ := cmd.Flags().GetString("function")
+	id, err := helpers.ResolveProjectFunctionID(ctx, qc, printer, projectID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	function, httpRes, err := qc.FunctionsAPI.FunctionsGet(ctx, id).Execute()
+	if err != nil {
+		return nil, helpers.FunctionRequestError(printer, "unable to find function", httpRes, err)
+	}
+	if function.ProjectId != projectID {
+		return nil, utils.UsageError(fmt.Sprintf("function %s is in project %s, not --from-project", function.Name, function.ProjectId))
+	}
+	return function, nil
+}
@@ -0,0 +1,84 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/qernal/cli-qernal/charm"
+	"github.com/qernal/cli-qernal/commands/auth"
+	"github.com/qernal/cli-qernal/pkg/client"
+	"github.com/qernal/cli-qernal/pkg/common"
+	"github.com/qernal/cli-qernal/pkg/helpers"
+	"github.com/qernal/cli-qernal/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+func NewRollbackCmd(printer *utils.Printer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rollback",
+		Short: "Return a function to an earlier revision",
+		Long: `Re-submit the spec of an earlier revision as a new revision of the function. The spec is read from
+the API, or from the local history if the API no longer has it. See functions history for the
+available revisions.`,
+		Example: "qernal functions rollback --project <project name> --function <function name> --to 3",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				return utils.UsageError("no arguments expected")
+			}
+
+			ctx := context.Background()
+			token, err := auth.GetQernalToken()
+			if err != nil {
+				return charm.RenderError("unable to retrieve qernal token, run qernal auth login if you haven't", err)
+			}
+
+			qc, err := client.New(ctx, nil, nil, token)
+			if err != nil {
+				return charm.RenderError("error creating qernal client", err)
+			}
+
+			functionID, err := functionFlagID(cmd, &qc, printer)
+			if err != nil {
+				return err
+			}
+
+			qFunc, httpRes, err := qc.FunctionsAPI.FunctionsGet(ctx, functionID).Execute()
+			if err != nil {
+				return helpers.FunctionRequestError(printer, "unable to find function", httpRes, err)
+			}
+
+			to, _ := cmd.Flags().GetString("to")
+			if qFunc.Revision == to {
+				return utils.UsageError(fmt.Sprintf("function %s is already at revision %s", qFunc.Name, to))
+			}
+
+			spec, err := helpers.FunctionRevisionSpec(ctx, &qc, printer, functionID, to)
+			if err != nil {
+				return charm.RenderError("unable to roll back function", err)
+			}
+
+			updated, err := helpers.UpdateFunction(ctx, &qc, printer, functionID, qFunc.Revision, spec)
+			if err != nil {
+				return err
+			}
+
+			switch {
+			case common.Quiet:
+				printer.PrintIDs(updated.Id)
+			case common.OutputFormat == "json":
+				printer.PrintResource(utils.FormatOutput(updated, common.OutputFormat))
+			default:
+				printer.PrintResource(charm.SuccessStyle.Render(fmt.Sprintf("rolled back function %s to revision %s, it is now at revision %s", updated.Name, to, updated.Revision)))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&functionID, "function", "f", "", functionFlagUsage)
+	cmd.Flags().String("to", "", "revision to roll back to, see functions history")
+	_ = cmd.MarkFlagRequired("function")
+	_ = cmd.MarkFlagRequired("to")
+	utils.AddQuietFlag(cmd)
+
+	return cmd
+}
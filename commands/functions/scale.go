@@ -0,0 +1,101 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/qernal/cli-qernal/charm"
+	"github.com/qernal/cli-qernal/commands/auth"
+	"github.com/qernal/cli-qernal/pkg/client"
+	"github.com/qernal/cli-qernal/pkg/common"
+	"github.com/qernal/cli-qernal/pkg/helpers"
+	"github.com/qernal/cli-qernal/pkg/utils"
+	openapi_chaos_client "github.com/qernal/openapi-chaos-go-client"
+	"github.com/spf13/cobra"
+)
+
+func NewScaleCmd(printer *utils.Printer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "scale",
+		Short: "Change the number of replicas of a function",
+		Long: `Change the minimum and maximum replicas of every deployment of a function, or only of the
+deployments in --location. The live function is updated directly, if it changes in the meantime the
+command fails with a conflict instead of overwriting the change.`,
+		Example: `  qernal functions scale --project <project name> --function api --min 2 --max 10
+
+  # Only scale the deployments in Europe
+  qernal functions scale --project <project name> --function api --max 20 --location Europe`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				return utils.UsageError("no arguments expected")
+			}
+			if !cmd.Flags().Changed("min") && !cmd.Flags().Changed("max") {
+				return utils.UsageError("at least one of --min or --max must be specified")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			token, err := auth.GetQernalToken()
+			if err != nil {
+				return charm.RenderError("unable to retrieve qernal token, run qernal auth login if you haven't", err)
+			}
+
+			qc, err := client.New(ctx, nil, nil, token)
+			if err != nil {
+				return charm.RenderError("error creating qernal client", err)
+			}
+
+			functionID, err := functionFlagID(cmd, &qc, printer)
+			if err != nil {
+				return err
+			}
+
+			var min, max *int32
+			if cmd.Flags().Changed("min") {
+				value, _ := cmd.Flags().GetInt32("min")
+				min = &value
+			}
+			if cmd.Flags().Changed("max") {
+				value, _ := cmd.Flags().GetInt32("max")
+				max = &value
+			}
+			location, _ := cmd.Flags().GetString("location")
+
+			qFunc, changed, err := helpers.ModifyFunction(ctx, &qc, printer, functionID, func(body *openapi_chaos_client.FunctionBody) error {
+				return helpers.ScaleDeployments(body, location, min, max)
+			})
+			if err != nil {
+				return err
+			}
+
+			message := fmt.Sprintf("scaled function %s, it is now at revision %s", qFunc.Name, qFunc.Revision)
+			if !changed {
+				message = fmt.Sprintf("function %s already has these replicas, nothing to do", qFunc.Name)
+			}
+			printModifiedFunction(printer, qFunc, message)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&functionID, "function", "f", "", functionFlagUsage)
+	cmd.Flags().Int32("min", 0, "minimum number of replicas")
+	cmd.Flags().Int32("max", 0, "maximum number of replicas")
+	cmd.Flags().String("location", "", "only scale deployments in this provider id, continent, country or city")
+	_ = cmd.MarkFlagRequired("function")
+	utils.AddQuietFlag(cmd)
+
+	return cmd
+}
+
+// printModifiedFunction prints a function changed by an imperative command
+func printModifiedFunction(printer *utils.Printer, qFunc *openapi_chaos_client.Function, message string) {
+	switch {
+	case common.Quiet:
+		printer.PrintIDs(qFunc.Id)
+	case common.OutputFormat == "json":
+		printer.PrintResource(utils.FormatOutput(qFunc, common.OutputFormat))
+	default:
+		printer.PrintResource(charm.SuccessStyle.Render(message))
+	}
+}
@@ -0,0 +1,90 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/qernal/cli-qernal/charm"
+	"github.com/qernal/cli-qernal/commands/auth"
+	"github.com/qernal/cli-qernal/pkg/client"
+	"github.com/qernal/cli-qernal/pkg/helpers"
+	"github.com/qernal/cli-qernal/pkg/utils"
+	openapi_chaos_client "github.com/qernal/openapi-chaos-go-client"
+	"github.com/spf13/cobra"
+)
+
+func NewSetCmd(printer *utils.Printer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set",
+		Short: "Change a single setting of a live function",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := cmd.Help()
+			if err != nil {
+				return err
+			}
+			return charm.RenderError("a valid subcommand is required")
+		},
+	}
+	cmd.AddCommand(NewSetImageCmd(printer))
+
+	return cmd
+}
+
+func NewSetImageCmd(printer *utils.Printer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "image",
+		Short: "Change the container image of a function",
+		Long: `Change the container image of a live function. If the function changes in the meantime the
+command fails with a conflict instead of overwriting the change.`,
+		Example: "qernal functions set image --project <project name> --function api --image nginx:1.27",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				return utils.UsageError("no arguments expected")
+			}
+
+			image, _ := cmd.Flags().GetString("image")
+			if image == "" {
+				return utils.UsageError("--image can't be empty")
+			}
+
+			ctx := context.Background()
+			token, err := auth.GetQernalToken()
+			if err != nil {
+				return charm.RenderError("unable to retrieve qernal token, run qernal auth login if you haven't", err)
+			}
+
+			qc, err := client.New(ctx, nil, nil, token)
+			if err != nil {
+				return charm.RenderError("error creating qernal client", err)
+			}
+
+			functionID, err := functionFlagID(cmd, &qc, printer)
+			if err != nil {
+				return err
+			}
+
+			qFunc, changed, err := helpers.ModifyFunction(ctx, &qc, printer, functionID, func(body *openapi_chaos_client.FunctionBody) error {
+				body.Image = image
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+
+			message := fmt.Sprintf("set image of function %s to %s, it is now at revision %s", qFunc.Name, image, qFunc.Revision)
+			if !changed {
+				message = fmt.Sprintf("function %s already runs %s, nothing to do", qFunc.Name, image)
+			}
+			printModifiedFunction(printer, qFunc, message)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&functionID, "function", "f", "", functionFlagUsage)
+	cmd.Flags().String("image", "", "container image, e.g. repo:tag")
+	_ = cmd.MarkFlagRequired("function")
+	_ = cmd.MarkFlagRequired("image")
+	utils.AddQuietFlag(cmd)
+
+	return cmd
+}
@@ -0,0 +1,61 @@
+package functions
+
+import (
+	"fmt"
+
+	"github.com/qernal/cli-qernal/charm"
+	"github.com/qernal/cli-qernal/pkg/common"
+	"github.com/qernal/cli-qernal/pkg/helpers"
+	"github.com/qernal/cli-qernal/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+func NewValidateCmd(printer *utils.Printer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Check function definition files for errors without deploying them",
+		Long: `Check every function in a definition file for unknown or missing fields, invalid values
+and settings that contradict each other. Problems are reported as file:line:column, no connection
+to Qernal is needed.`,
+		Example: "qernal functions validate -f functions.yaml",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			vars, err := definitionVariables(cmd)
+			if err != nil {
+				return err
+			}
+
+			files, _ := cmd.Flags().GetStringArray("file")
+			sources, err := helpers.ReadFunctionSources(files, cmd.InOrStdin())
+			if err != nil {
+				return charm.RenderError("unable to validate function config", err)
+			}
+
+			problems := helpers.ValidateFunctionSources(sources, vars)
+
+			if common.OutputFormat == "json" {
+				printer.PrintResource(utils.FormatOutput(map[string]interface{}{
+					"valid":  len(problems) == 0,
+					"errors": problems,
+				}, common.OutputFormat))
+			} else if !common.Quiet {
+				for _, problem := range problems {
+					printer.PrintResource(problem.Error())
+				}
+				if len(problems) == 0 {
+					printer.PrintResource(charm.SuccessStyle.Render(fmt.Sprintf("%d files are valid", len(sources))))
+				}
+			}
+
+			if len(problems) > 0 {
+				return utils.UsageError(fmt.Sprintf("found %d problems in %d files", len(problems), len(sources)))
+			}
+			return nil
+		},
+	}
+
+	addDefinitionFlags(cmd)
+	_ = cmd.MarkFlagRequired("file")
+	utils.AddQuietFlag(cmd)
+
+	return cmd
+}
@@ -0,0 +1,177 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/qernal/cli-qernal/charm"
+	"github.com/qernal/cli-qernal/commands/auth"
+	"github.com/qernal/cli-qernal/pkg/client"
+	"github.com/qernal/cli-qernal/pkg/common"
+	"github.com/qernal/cli-qernal/pkg/helpers"
+	"github.com/qernal/cli-qernal/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// waitResult is printed once a wait condition holds
+type waitResult struct {
+	Function string `json:"function"`
+	For      string `json:"for"`
+	Status   string `json:"status"`
+}
+
+func NewWaitCmd(printer *utils.Printer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "wait",
+		Short: "Wait until a function is serving or deleted",
+		Long: `Block until a condition holds for a function, for use after create, update or apply in CI.
+
+  --for ready     the function is at the current (or --revision) revision and every deployment
+                  location runs at least its minimum replicas of it
+  --for revision  the function is at the current (or --revision) revision, without checking replicas
+  --for deleted   the function no longer exists
+
+The API doesn't report rollouts, so readiness is inferred from telemetry. A container counts as a
+replica of the revision once it logs or has a platform event after the revision was applied, and
+is placed in a location by the labels of its logs. Containers that were already logging before
+then belong to an earlier revision. The apply time is known for revisions applied from this
+machine, or when the wait sees the function change revision. If the function is already at the
+revision and the apply time isn't known, every container that is still reporting counts. Metrics
+aren't used, they are aggregated per function and can't tell containers or revisions apart.
+Functions that never log, such as quiet workers, can use --for revision instead.
+
+API errors while polling are retried until --timeout, unless the function doesn't exist or the
+token is rejected. Exits with code 7 if the condition doesn't hold within --timeout.`,
+		Example: `  qernal functions apply -f functions.yaml
+  qernal functions wait --project <project name> --function api --for ready --timeout 5m`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				return utils.UsageError("no arguments expected")
+			}
+			condition, _ := cmd.Flags().GetString("for")
+			if condition != "ready" && condition != "revision" && condition != "deleted" {
+				return utils.UsageError(fmt.Sprintf("--for must be ready, revision or deleted, got %s", condition))
+			}
+			timeout, _ := cmd.Flags().GetDuration("timeout")
+			interval, _ := cmd.Flags().GetDuration("poll-interval")
+			if timeout <= 0 || interval <= 0 {
+				return utils.UsageError("--timeout and --poll-interval must be greater than zero")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			condition, _ := cmd.Flags().GetString("for")
+			timeout, _ := cmd.Flags().GetDuration("timeout")
+			interval, _ := cmd.Flags().GetDuration("poll-interval")
+			revision, _ := cmd.Flags().GetString("revision")
+
+			token, err := auth.GetQernalToken()
+			if err != nil {
+				return charm.RenderError("unable to retrieve qernal token, run qernal auth login if you haven't", err)
+			}
+
+			qc, err := client.New(context.Background(), nil, nil, token)
+			if err != nil {
+				return charm.RenderError("error creating qernal client", err)
+			}
+
+			functionID, err := functionFlagID(cmd, &qc, printer)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+
+			// since is the last time the function was seen at another revision, zero until it is
+			var since time.Time
+			poll := func(ctx context.Context) (bool, string, error) {
+				status, err := helpers.GetRolloutStatus(ctx, &qc, printer, functionID, revision, since)
+				if err != nil {
+					return false, "", err
+				}
+				// pin the revision, so a later update isn't mistaken for this rollout
+				revision = status.WantRevision
+				if status.Revision != status.WantRevision {
+					since = time.Now()
+				}
+				done, message := status.Ready()
+				return done, message, nil
+			}
+			if condition == "revision" {
+				poll = func(ctx context.Context) (bool, string, error) {
+					qFunc, httpRes, err := qc.FunctionsAPI.FunctionsGet(ctx, functionID).Execute()
+					if err != nil {
+						return false, "", helpers.FunctionRequestError(printer, "unable to get function", httpRes, err)
+					}
+					if revision == "" {
+						revision = qFunc.Revision
+					}
+					if qFunc.Revision != revision {
+						return false, fmt.Sprintf("function %s is at revision %s, waiting for revision %s", qFunc.Name, qFunc.Revision, revision), nil
+					}
+					return true, fmt.Sprintf("function %s is at revision %s", qFunc.Name, qFunc.Revision), nil
+				}
+			}
+			if condition == "deleted" {
+				poll = func(ctx context.Context) (bool, string, error) {
+					_, httpRes, err := qc.FunctionsAPI.FunctionsGet(ctx, functionID).Execute()
+					if httpRes != nil && httpRes.StatusCode == 404 {
+						return true, fmt.Sprintf("function %s is deleted", functionID), nil
+					}
+					if err != nil {
+						return false, "", helpers.FunctionRequestError(printer, "unable to get function", httpRes, err)
+					}
+					return false, fmt.Sprintf("function %s still exists", functionID), nil
+				}
+			}
+
+			status, err := charm.WaitFor(ctx, interval, common.Quiet || common.OutputFormat == "json", retryPoll(printer, poll))
+			if err != nil {
+				if ctx.Err() == context.DeadlineExceeded {
+					return utils.NewError(utils.ExitTimeout, fmt.Sprintf("timed out after %s waiting for function to be %s: %s", timeout, condition, status), err)
+				}
+				return err
+			}
+
+			switch {
+			case common.Quiet:
+				printer.PrintIDs(functionID)
+			case common.OutputFormat == "json":
+				printer.PrintResource(utils.FormatOutput(waitResult{Function: functionID, For: condition, Status: status}, common.OutputFormat))
+			default:
+				printer.PrintResource(charm.SuccessStyle.Render(status))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&functionID, "function", "f", "", functionFlagUsage)
+	cmd.Flags().String("for", "ready", "condition to wait for, ready, revision or deleted")
+	cmd.Flags().Duration("timeout", 5*time.Minute, "how long to wait before failing")
+	cmd.Flags().Duration("poll-interval", 5*time.Second, "how often to check the function")
+	cmd.Flags().String("revision", "", "revision to wait for, defaults to the current revision")
+	_ = cmd.MarkFlagRequired("function")
+	utils.AddQuietFlag(cmd)
+
+	return cmd
+}
+
+// retryPoll retries failed polls on the next interval, so a short API error during a rollout doesn't
+// end the wait. Errors that won't go away by waiting, a missing function or a rejected token, still do.
+func retryPoll(printer *utils.Printer, poll charm.PollFunc) charm.PollFunc {
+	return func(ctx context.Context) (bool, string, error) {
+		done, message, err := poll(ctx)
+		if err == nil || ctx.Err() != nil {
+			return done, message, err
+		}
+		switch utils.ExitCode(err) {
+		case utils.ExitNotFound, utils.ExitAuth, utils.ExitUsage:
+			return false, "", err
+		}
+		printer.Logger.Debug("poll failed, retrying", slog.String("error", err.Error()))
+		return false, fmt.Sprintf("retrying, %s", err.Error()), nil
+	}
+}
@@ -0,0 +1,46 @@
+package functions
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/qernal/cli-qernal/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPoll(t *testing.T) {
+	testCases := []struct {
+		name  string
+		err   error
+		retry bool
+	}{
+		{name: "api error", err: utils.NewError(utils.ExitAPI, "unable to get function", errors.New("502 Bad Gateway")), retry: true},
+		{name: "network error", err: errors.New("connection reset by peer"), retry: true},
+		{name: "not found", err: utils.NewError(utils.ExitNotFound, "unable to get function"), retry: false},
+		{name: "rejected token", err: utils.NewError(utils.ExitAuth, "unable to get function"), retry: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			poll := retryPoll(utils.NewPrinter(), func(ctx context.Context) (bool, string, error) {
+				return false, "", tc.err
+			})
+			done, message, err := poll(context.Background())
+			assert.False(t, done)
+			if tc.retry {
+				assert.NoError(t, err)
+				assert.Contains(t, message, "retrying")
+			} else {
+				assert.ErrorIs(t, err, tc.err)
+			}
+		})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, err := retryPoll(utils.NewPrinter(), func(ctx context.Context) (bool, string, error) {
+		return false, "", ctx.Err()
+	})(ctx)
+	assert.ErrorIs(t, err, context.Canceled, "errors after the wait ended aren't retried")
+}
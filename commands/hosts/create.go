@@ -2,9 +2,9 @@ package hosts
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"strings"
 
 	"github.com/qernal/cli-qernal/charm"
@@ -29,7 +29,7 @@ func NewCreateCmd(printer *utils.Printer) *cobra.Command {
 			ctx := context.Background()
 			token, err := auth.GetQernalToken()
 			if err != nil {
-				return charm.RenderError("unable to retrieve qernal token, run qernal auth login if you haven't")
+				return charm.RenderError("unable to retrieve qernal token, run qernal auth login if you haven't", err)
 			}
 
 			qc, err := client.New(ctx, nil, nil, token)
@@ -45,18 +45,27 @@ func NewCreateCmd(printer *utils.Printer) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			host, httpRes, err := qc.HostsAPI.ProjectsHostsCreate(ctx, projectID).HostBody(openapi_chaosclient.HostBody{
+			body := openapi_chaosclient.HostBody{
 				Host:        hostName,
 				Certificate: fmt.Sprintf("projects:%s/%s", projectID, strings.ToUpper(cert)),
 				Disabled:    isDisabled,
-			}).Execute()
+			}
+			if helpers.DryRun(cmd) {
+				return helpers.PrintDryRun(printer, helpers.DryRunRequest{
+					Method: http.MethodPost,
+					Path:   fmt.Sprintf("/projects/%s/hosts", projectID),
+					Body:   body,
+				})
+			}
+
+			host, httpRes, err := qc.HostsAPI.ProjectsHostsCreate(ctx, projectID).HostBody(body).Execute()
 
 			if err != nil {
 				resData, _ := client.ParseResponseData(httpRes)
 				if data, ok := resData.(map[string]interface{}); ok {
 					if innerData, ok := data["data"].(map[string]interface{}); ok {
 						if nameErr, ok := innerData["name"].(string); ok {
-							return printer.RenderError("unable to create host", errors.New(nameErr))
+							return printer.RenderError("unable to create host", client.NewResponseError(nameErr, err))
 						}
 					}
 				}
@@ -66,6 +75,20 @@ func NewCreateCmd(printer *utils.Printer) *cobra.Command {
 				return printer.RenderError("unable to create host", err)
 			}
 
+			dnsRecords := map[string]string{
+				"A":    publicIPV4,
+				"AAAA": publicIPV6,
+				"TXT":  host.TxtVerification,
+			}
+			verificationNotice := "Please add the TXT record for host verification, then update your A and AAAA records\nwhen verification is complete. The host will not be routable until the verification has completed.\n"
+
+			if common.Quiet {
+				printer.PrintIDs(host.Host)
+				printer.PrintWarning(verificationNotice)
+				printer.PrintWarning(charm.RenderDNSTable(dnsRecords))
+				return nil
+			}
+
 			var data interface{}
 			if common.OutputFormat == "json" {
 				data = host
@@ -76,24 +99,21 @@ func NewCreateCmd(printer *utils.Printer) *cobra.Command {
 					"Enabled":         host.Disabled,
 				}
 			}
-			dnsRecords := map[string]string{
-				"A":    publicIPV4,
-				"AAAA": publicIPV6,
-				"TXT":  host.TxtVerification,
-			}
 
 			printer.PrintResource(utils.FormatOutput(data, common.OutputFormat))
-			printer.PrintResource(charm.RenderWarning("Please add the TXT record for host verification, then update your A and AAAA records\nwhen verification is complete. The host will not be routable until the verification has completed.\n"))
-			fmt.Println(charm.RenderDNSTable(dnsRecords))
+			printer.PrintResource(charm.RenderWarning(verificationNotice))
+			printer.PrintResource(charm.RenderDNSTable(dnsRecords))
 			return nil
 		},
 	}
 
 	cmd.Flags().StringVarP(&hostName, "name", "n", "", "name of the host")
+	helpers.AddDryRunFlag(cmd)
 
 	_ = cmd.MarkFlagRequired("name")
 	_ = cmd.MarkFlagRequired("project")
 	_ = cmd.MarkFlagRequired("cert")
+	utils.AddQuietFlag(cmd)
 
 	return cmd
 }
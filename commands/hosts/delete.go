@@ -2,8 +2,9 @@ package hosts
 
 import (
 	"context"
-	"errors"
+	"fmt"
 	"log/slog"
+	"net/http"
 
 	"github.com/qernal/cli-qernal/charm"
 	"github.com/qernal/cli-qernal/commands/auth"
@@ -24,13 +25,13 @@ func NewDeleteCmd(printer *utils.Printer) *cobra.Command {
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) > 0 {
-				return charm.RenderError("No arguments expected")
+				return utils.UsageError("no arguments expected")
 			}
 
 			ctx := context.Background()
 			token, err := auth.GetQernalToken()
 			if err != nil {
-				return charm.RenderError("unable to retreive qernal token, run qernal auth login if you haven't")
+				return charm.RenderError("unable to retreive qernal token, run qernal auth login if you haven't", err)
 
 			}
 
@@ -46,12 +47,19 @@ func NewDeleteCmd(printer *utils.Printer) *cobra.Command {
 				return err
 			}
 
+			if helpers.DryRun(cmd) {
+				return helpers.PrintDryRun(printer, helpers.DryRunRequest{
+					Method: http.MethodDelete,
+					Path:   fmt.Sprintf("/projects/%s/hosts/%s", projectID, hostName),
+				})
+			}
+
 			DeleteResp, httpRes, err := qc.HostsAPI.ProjectsHostsDelete(ctx, projectID, hostName).Execute()
 			if err != nil {
 				resData, _ := client.ParseResponseData(httpRes)
 				if data, ok := resData.(map[string]interface{}); ok {
 					if innerData, ok := data["data"].(map[string]interface{}); ok {
-						return charm.RenderError("unable to delete host: ", errors.New(innerData["name"].(string)))
+						return charm.RenderError("unable to delete host: ", client.NewResponseError(innerData["name"].(string), err))
 					}
 				}
 				printer.Logger.Debug("unable to delete host, request failed",
@@ -73,6 +81,7 @@ func NewDeleteCmd(printer *utils.Printer) *cobra.Command {
 
 		},
 	}
+	helpers.AddDryRunFlag(cmd)
 	_ = cmd.MarkFlagRequired("name")
 	_ = cmd.MarkFlagRequired("project")
 
@@ -2,7 +2,6 @@ package hosts
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"strings"
 
@@ -26,13 +25,13 @@ func NewGetCmd(printer *utils.Printer) *cobra.Command {
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) > 0 {
-				return charm.RenderError("No arguments expected")
+				return utils.UsageError("no arguments expected")
 			}
 
 			ctx := context.Background()
 			token, err := auth.GetQernalToken()
 			if err != nil {
-				return charm.RenderError("unable to retreive qernal token, run qernal auth login if you haven't")
+				return charm.RenderError("unable to retreive qernal token, run qernal auth login if you haven't", err)
 
 			}
 
@@ -48,59 +47,61 @@ func NewGetCmd(printer *utils.Printer) *cobra.Command {
 				return err
 			}
 
-			// check if host needs verification
-			host, httpRes, err := qc.HostsAPI.ProjectsHostsGet(ctx, projectID, hostName).Execute()
-			if err != nil {
-				resData, _ := client.ParseResponseData(httpRes)
-				if data, ok := resData.(map[string]interface{}); ok {
-					if innerData, ok := data["data"].(map[string]interface{}); ok {
-						if nameErr, ok := innerData["name"].(string); ok {
-							return printer.RenderError("unable to find host", errors.New(nameErr))
+			return printer.Watch(func() error {
+				// check if host needs verification
+				host, httpRes, err := qc.HostsAPI.ProjectsHostsGet(ctx, projectID, hostName).Execute()
+				if err != nil {
+					resData, _ := client.ParseResponseData(httpRes)
+					if data, ok := resData.(map[string]interface{}); ok {
+						if innerData, ok := data["data"].(map[string]interface{}); ok {
+							if nameErr, ok := innerData["name"].(string); ok {
+								return printer.RenderError("unable to find host", client.NewResponseError(nameErr, err))
+							}
 						}
 					}
+					return charm.RenderError("unable to find host", err)
 				}
-				return charm.RenderError("unable to find host", err)
-			}
 
-			var data interface{}
-			if common.OutputFormat == "json" {
-				data = host
-			} else {
-				// Format relevant host information for text output
-				routeable := ""
-				if host.VerificationStatus != "completed" && !host.Disabled {
-					routeable = " (unroutable, not verified)"
-				}
+				var data interface{}
+				if common.OutputFormat == "json" {
+					data = host
+				} else {
+					// Format relevant host information for text output
+					routeable := ""
+					if host.VerificationStatus != "completed" && !host.Disabled {
+						routeable = " (unroutable, not verified)"
+					}
 
-				certName := "None"
-				if host.Certificate != nil && *host.Certificate != "" {
-					certRefParts := strings.Split(*host.Certificate, "/")
-					certName = certRefParts[len(certRefParts)-1]
-				}
+					certName := "None"
+					if host.Certificate != nil && *host.Certificate != "" {
+						certRefParts := strings.Split(*host.Certificate, "/")
+						certName = certRefParts[len(certRefParts)-1]
+					}
 
-				// TODO: persist order of this render
-				data = map[string]interface{}{
-					"Hostname":                host.Host,
-					"Project ID":              host.ProjectId,
-					"State":                   fmt.Sprintf("%s%s", helpers.GetHostState(host.Disabled), routeable),
-					"Certificate":             certName,
-					"Read Only":               helpers.GetReadOnlyStatus(host.ReadOnly),
-					"Verification TXT Record": host.TxtVerification,
-					"Verification Status":     string(host.VerificationStatus),
-					"-------------------":     "",
-					"A Record":                publicIPV4,
-					"AAAA Record":             publicIPV6,
+					// TODO: persist order of this render
+					data = map[string]interface{}{
+						"Hostname":                host.Host,
+						"Project ID":              host.ProjectId,
+						"State":                   fmt.Sprintf("%s%s", helpers.GetHostState(host.Disabled), routeable),
+						"Certificate":             certName,
+						"Read Only":               helpers.GetReadOnlyStatus(host.ReadOnly),
+						"Verification TXT Record": host.TxtVerification,
+						"Verification Status":     string(host.VerificationStatus),
+						"-------------------":     "",
+						"A Record":                publicIPV4,
+						"AAAA Record":             publicIPV6,
+					}
 				}
-			}
-
-			response := charm.SuccessStyle.Render(utils.FormatOutput(data, common.OutputFormat))
-			printer.PrintResource(response)
-			return nil
 
+				response := charm.SuccessStyle.Render(utils.FormatOutput(data, common.OutputFormat))
+				printer.PrintResource(response)
+				return nil
+			})
 		},
 	}
 	_ = cmd.MarkFlagRequired("name")
 	_ = cmd.MarkFlagRequired("project")
+	utils.AddWatchFlags(cmd)
 
 	return cmd
 }
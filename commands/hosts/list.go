@@ -2,7 +2,6 @@ package hosts
 
 import (
 	"context"
-	"errors"
 
 	"github.com/qernal/cli-qernal/charm"
 	"github.com/qernal/cli-qernal/commands/auth"
@@ -25,7 +24,7 @@ func NewListCmd(printer *utils.Printer) *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			token, err := auth.GetQernalToken()
 			if err != nil {
-				return charm.RenderError("unable to retrieive qernal token, run qernal auth login if you haven't")
+				return charm.RenderError("unable to retrieive qernal token, run qernal auth login if you haven't", err)
 			}
 			ctx := context.Background()
 			qc, err := client.New(ctx, nil, nil, token)
@@ -39,29 +38,42 @@ func NewListCmd(printer *utils.Printer) *cobra.Command {
 				return err
 			}
 
-			hostResp, httpRes, err := qc.HostsAPI.ProjectsHostsList(ctx, projectID).Execute()
-			if err != nil {
-				resData, _ := client.ParseResponseData(httpRes)
-				if data, ok := resData.(map[string]interface{}); ok {
-					if innerData, ok := data["data"].(map[string]interface{}); ok {
-						if nameErr, ok := innerData["name"].(string); ok {
-							return printer.RenderError("unable to list hosts", errors.New(nameErr))
+			return printer.Watch(func() error {
+				hostResp, httpRes, err := qc.HostsAPI.ProjectsHostsList(ctx, projectID).Execute()
+				if err != nil {
+					resData, _ := client.ParseResponseData(httpRes)
+					if data, ok := resData.(map[string]interface{}); ok {
+						if innerData, ok := data["data"].(map[string]interface{}); ok {
+							if nameErr, ok := innerData["name"].(string); ok {
+								return printer.RenderError("unable to list hosts", client.NewResponseError(nameErr, err))
+							}
 						}
 					}
+					return printer.RenderError("unable to list hosts", err)
 				}
-			}
 
-			if common.OutputFormat == "json" {
-				printer.PrintResource(utils.FormatOutput(hostResp.Data, common.OutputFormat))
-				return nil
-			}
+				// hosts are addressed by hostname in every other command
+				if common.Quiet {
+					for _, host := range hostResp.Data {
+						printer.PrintIDs(host.Host)
+					}
+					return nil
+				}
+
+				if common.OutputFormat == "json" {
+					printer.PrintResource(utils.FormatOutput(hostResp.Data, common.OutputFormat))
+					return nil
+				}
 
-			table := charm.RenderHostTable(hostResp.Data)
-			printer.PrintResource(table)
-			return nil
+				table := charm.RenderHostTable(hostResp.Data)
+				printer.PrintResource(table)
+				return nil
+			})
 		},
 	}
 	cmd.Flags().StringVarP(&common.OutputFormat, "output", "o", "text", "output format (json,text)")
 	_ = cmd.MarkFlagRequired("project")
+	utils.AddQuietFlag(cmd)
+	utils.AddWatchFlags(cmd)
 	return cmd
 }
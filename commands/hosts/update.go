@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"strings"
 
 	"github.com/qernal/cli-qernal/charm"
@@ -41,7 +42,7 @@ func NewUpdateCmd(printer *utils.Printer) *cobra.Command {
 			ctx := context.Background()
 			token, err := auth.GetQernalToken()
 			if err != nil {
-				return charm.RenderError("unable to retreive qernal token, run qernal auth login if you haven't")
+				return charm.RenderError("unable to retreive qernal token, run qernal auth login if you haven't", err)
 
 			}
 
@@ -60,16 +61,25 @@ func NewUpdateCmd(printer *utils.Printer) *cobra.Command {
 			}
 
 			ref := fmt.Sprintf("projects:%s/%s", projectID, strings.ToUpper(cert))
-			_, httpRes, err := qc.HostsAPI.ProjectsHostsUpdate(ctx, projectID, hostName).HostBodyPatch(openapi_chaos_client.HostBodyPatch{
+			body := openapi_chaos_client.HostBodyPatch{
 				Certificate: &ref,
 				Disabled:    &isEnabled,
-			}).Execute()
+			}
+			if helpers.DryRun(cmd) {
+				return helpers.PrintDryRun(printer, helpers.DryRunRequest{
+					Method: http.MethodPut,
+					Path:   fmt.Sprintf("/projects/%s/hosts/%s", projectID, hostName),
+					Body:   body,
+				})
+			}
+
+			_, httpRes, err := qc.HostsAPI.ProjectsHostsUpdate(ctx, projectID, hostName).HostBodyPatch(body).Execute()
 			if err != nil {
 				resData, _ := client.ParseResponseData(httpRes)
 				if data, ok := resData.(map[string]interface{}); ok {
 					if innerData, ok := data["data"].(map[string]interface{}); ok {
 						if nameErr, ok := innerData["name"].(string); ok {
-							err = errors.New(nameErr)
+							err = client.NewResponseError(nameErr, err)
 						}
 					}
 				}
@@ -87,6 +97,7 @@ func NewUpdateCmd(printer *utils.Printer) *cobra.Command {
 	}
 
 	cmd.Flags().StringVarP(&common.OutputFormat, "output", "o", "text", "output format (json,text)")
+	helpers.AddDryRunFlag(cmd)
 	_ = cmd.MarkFlagRequired("project")
 	_ = cmd.MarkFlagRequired("name")
 	return cmd
@@ -27,7 +27,7 @@ func NewVerifyCmd(printer *utils.Printer) *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			token, err := auth.GetQernalToken()
 			if err != nil {
-				return charm.RenderError("unable to retrieive qernal token, run qernal auth login if you haven't")
+				return charm.RenderError("unable to retrieive qernal token, run qernal auth login if you haven't", err)
 			}
 			ctx := context.Background()
 			qc, err := client.New(ctx, nil, nil, token)
@@ -51,7 +51,7 @@ func NewVerifyCmd(printer *utils.Printer) *cobra.Command {
 				if data, ok := resData.(map[string]interface{}); ok {
 					if innerData, ok := data["data"].(map[string]interface{}); ok {
 						if nameErr, ok := innerData["name"].(string); ok {
-							return printer.RenderError("unable to find host", errors.New(nameErr))
+							return printer.RenderError("unable to find host", client.NewResponseError(nameErr, err))
 						}
 					}
 				}
@@ -77,7 +77,7 @@ func NewVerifyCmd(printer *utils.Printer) *cobra.Command {
 				if data, ok := resData.(map[string]interface{}); ok {
 					if innerData, ok := data["data"].(map[string]interface{}); ok {
 						if nameErr, ok := innerData["name"].(string); ok {
-							return printer.RenderError("unable to verify hosts", errors.New(nameErr))
+							return printer.RenderError("unable to verify hosts", client.NewResponseError(nameErr, err))
 						}
 					}
 				}
@@ -2,13 +2,14 @@ package org
 
 import (
 	"context"
-	"errors"
 	"log/slog"
+	"net/http"
 
 	"github.com/qernal/cli-qernal/charm"
 	"github.com/qernal/cli-qernal/commands/auth"
 	"github.com/qernal/cli-qernal/pkg/client"
 	"github.com/qernal/cli-qernal/pkg/common"
+	"github.com/qernal/cli-qernal/pkg/helpers"
 	"github.com/qernal/cli-qernal/pkg/utils"
 	openapi_chaos_client "github.com/qernal/openapi-chaos-go-client"
 	"github.com/spf13/cobra"
@@ -24,7 +25,7 @@ func NewCreateCmd(printer *utils.Printer) *cobra.Command {
 			ctx := context.Background()
 			token, err := auth.GetQernalToken()
 			if err != nil {
-				return charm.RenderError("unable to retreive qernal token, run qernal auth login if you haven't")
+				return charm.RenderError("unable to retreive qernal token, run qernal auth login if you haven't", err)
 
 			}
 			qc, err := client.New(ctx, nil, nil, token)
@@ -34,15 +35,20 @@ func NewCreateCmd(printer *utils.Printer) *cobra.Command {
 
 			orgName, _ := cmd.Flags().GetString("organisation")
 
-			org, httpRes, err := qc.OrganisationsAPI.OrganisationsCreate(ctx).OrganisationBody(openapi_chaos_client.OrganisationBody{
+			body := openapi_chaos_client.OrganisationBody{
 				Name: orgName,
-			}).Execute()
+			}
+			if helpers.DryRun(cmd) {
+				return helpers.PrintDryRun(printer, helpers.DryRunRequest{Method: http.MethodPost, Path: "/organisations", Body: body})
+			}
+
+			org, httpRes, err := qc.OrganisationsAPI.OrganisationsCreate(ctx).OrganisationBody(body).Execute()
 			if err != nil {
 				resData, _ := client.ParseResponseData(httpRes)
 				if data, ok := resData.(map[string]interface{}); ok {
 					if innerData, ok := data["data"].(map[string]interface{}); ok {
 						if nameErr, ok := innerData["name"].(string); ok {
-							return printer.RenderError("unable to create organisation", errors.New(nameErr))
+							return printer.RenderError("unable to create organisation", client.NewResponseError(nameErr, err))
 						}
 					}
 				}
@@ -52,6 +58,12 @@ func NewCreateCmd(printer *utils.Printer) *cobra.Command {
 
 				return printer.RenderError("unable to create organisation", err)
 			}
+
+			if common.Quiet {
+				printer.PrintIDs(org.Id)
+				return nil
+			}
+
 			var data interface{}
 
 			if common.OutputFormat == "json" {
@@ -67,6 +79,8 @@ func NewCreateCmd(printer *utils.Printer) *cobra.Command {
 		},
 	}
 	cmd.Flags().StringVarP(&common.OutputFormat, "output", "o", "text", "output format (json,text)")
+	helpers.AddDryRunFlag(cmd)
 	_ = cmd.MarkFlagRequired("organisation")
+	utils.AddQuietFlag(cmd)
 	return cmd
 }
@@ -2,13 +2,14 @@ package org
 
 import (
 	"context"
-	"errors"
 	"log/slog"
+	"net/http"
 
 	"github.com/qernal/cli-qernal/charm"
 	"github.com/qernal/cli-qernal/commands/auth"
 	"github.com/qernal/cli-qernal/pkg/client"
 	"github.com/qernal/cli-qernal/pkg/common"
+	"github.com/qernal/cli-qernal/pkg/helpers"
 	"github.com/qernal/cli-qernal/pkg/utils"
 	"github.com/spf13/cobra"
 )
@@ -20,13 +21,13 @@ func NewDeleteCmd(printer *utils.Printer) *cobra.Command {
 		Example: "qernal organisation delete --name <org name>",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) > 0 {
-				return charm.RenderError("No arguments expected")
+				return utils.UsageError("no arguments expected")
 			}
 
 			ctx := context.Background()
 			token, err := auth.GetQernalToken()
 			if err != nil {
-				return charm.RenderError("unable to retreive qernal token, run qernal auth login if you haven't")
+				return charm.RenderError("unable to retreive qernal token, run qernal auth login if you haven't", err)
 
 			}
 
@@ -42,12 +43,16 @@ func NewDeleteCmd(printer *utils.Printer) *cobra.Command {
 				return charm.RenderError("x", err)
 			}
 
+			if helpers.DryRun(cmd) {
+				return helpers.PrintDryRun(printer, helpers.DryRunRequest{Method: http.MethodDelete, Path: "/organisations/" + org.Id})
+			}
+
 			DeleteResp, httpRes, err := qc.OrganisationsAPI.OrganisationsDelete(ctx, org.Id).Execute()
 			if err != nil {
 				resData, _ := client.ParseResponseData(httpRes)
 				if data, ok := resData.(map[string]interface{}); ok {
 					if innerData, ok := data["data"].(map[string]interface{}); ok {
-						return charm.RenderError("unable to delete organisation: ", errors.New(innerData["name"].(string)))
+						return charm.RenderError("unable to delete organisation: ", client.NewResponseError(innerData["name"].(string), err))
 					}
 				}
 				printer.Logger.Debug("unable to delete org, request failed",
@@ -69,6 +74,7 @@ func NewDeleteCmd(printer *utils.Printer) *cobra.Command {
 
 		},
 	}
+	helpers.AddDryRunFlag(cmd)
 	_ = cmd.MarkFlagRequired("orgnaisation")
 	return cmd
 }
@@ -18,13 +18,13 @@ func NewGetCmd(printer *utils.Printer) *cobra.Command {
 		Example: "qernal organisation get --name <org name>",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) > 0 {
-				return charm.RenderError("No arguments expected")
+				return utils.UsageError("no arguments expected")
 			}
 
 			ctx := context.Background()
 			token, err := auth.GetQernalToken()
 			if err != nil {
-				return charm.RenderError("unable to retreive qernal token, run qernal auth login if you haven't")
+				return charm.RenderError("unable to retreive qernal token, run qernal auth login if you haven't", err)
 
 			}
 
@@ -35,35 +35,37 @@ func NewGetCmd(printer *utils.Printer) *cobra.Command {
 
 			orgName, _ := cmd.Flags().GetString("organisation")
 
-			org, err := qc.GetOrgByName(orgName)
-			if err != nil {
-				return printer.RenderError("x", err)
-			}
-
-			var data interface{}
-			if common.OutputFormat == "json" {
-				data = org
-			} else {
-				data = map[string]interface{}{
-					"Name":    org.Name,
-					"User ID": org.UserId,
-					"Org ID":  org.Id,
+			return printer.Watch(func() error {
+				org, err := qc.GetOrgByName(orgName)
+				if err != nil {
+					return printer.RenderError("x", err)
 				}
-			}
 
-			if common.OutputFormat == "json" {
-				printer.PrintResource(utils.FormatOutput(data, common.OutputFormat))
-				return nil
-			}
+				var data interface{}
+				if common.OutputFormat == "json" {
+					data = org
+				} else {
+					data = map[string]interface{}{
+						"Name":    org.Name,
+						"User ID": org.UserId,
+						"Org ID":  org.Id,
+					}
+				}
 
-			response := charm.SuccessStyle.Render(utils.FormatOutput(data, common.OutputFormat))
-			printer.PrintResource(response)
-			return nil
+				if common.OutputFormat == "json" {
+					printer.PrintResource(utils.FormatOutput(data, common.OutputFormat))
+					return nil
+				}
 
+				response := charm.SuccessStyle.Render(utils.FormatOutput(data, common.OutputFormat))
+				printer.PrintResource(response)
+				return nil
+			})
 		},
 	}
 	cmd.Flags().StringVarP(&common.OutputFormat, "output", "o", "text", "output format (json,text)")
 
 	_ = cmd.MarkFlagRequired("organisation")
+	utils.AddWatchFlags(cmd)
 	return cmd
 }
@@ -21,7 +21,7 @@ func NewOrgListCmd(printer *utils.Printer) *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			token, err := auth.GetQernalToken()
 			if err != nil {
-				return charm.RenderError("unable to retrieive qernal token, run qernal auth login if you haven't")
+				return charm.RenderError("unable to retrieive qernal token, run qernal auth login if you haven't", err)
 			}
 			ctx := context.Background()
 			qc, err := client.New(ctx, nil, nil, token)
@@ -31,25 +31,36 @@ func NewOrgListCmd(printer *utils.Printer) *cobra.Command {
 
 			maxResults, _ := cmd.Flags().GetInt32("max")
 
-			orgs, err := helpers.PaginateOrganisations(printer, ctx, &qc, maxResults)
-			if err != nil {
-				return charm.RenderError("unable to list organisations", err)
-			}
-			if maxResults > 0 && len(orgs) > int(maxResults) {
-				orgs = orgs[:maxResults]
-			}
+			return printer.Watch(func() error {
+				orgs, err := helpers.PaginateOrganisations(printer, ctx, &qc, maxResults)
+				if err != nil {
+					return charm.RenderError("unable to list organisations", err)
+				}
+				if maxResults > 0 && len(orgs) > int(maxResults) {
+					orgs = orgs[:maxResults]
+				}
 
-			if common.OutputFormat == "json" {
-				printer.PrintResource(utils.FormatOutput(orgs, common.OutputFormat))
-				return nil
-			}
+				if common.Quiet {
+					for _, org := range orgs {
+						printer.PrintIDs(org.Id)
+					}
+					return nil
+				}
+
+				if common.OutputFormat == "json" {
+					printer.PrintResource(utils.FormatOutput(orgs, common.OutputFormat))
+					return nil
+				}
 
-			table := charm.RenderOrgTable(orgs)
-			printer.PrintResource(table)
-			return nil
+				table := charm.RenderOrgTable(orgs)
+				printer.PrintResource(table)
+				return nil
+			})
 		},
 	}
 	cmd.Flags().StringVarP(&common.OutputFormat, "output", "o", "text", "output format (json,text)")
+	utils.AddQuietFlag(cmd)
+	utils.AddWatchFlags(cmd)
 
 	return cmd
 }
\ No newline at end of file
@@ -2,12 +2,13 @@ package org
 
 import (
 	"context"
-	"errors"
+	"net/http"
 
 	"github.com/qernal/cli-qernal/charm"
 	"github.com/qernal/cli-qernal/commands/auth"
 	"github.com/qernal/cli-qernal/pkg/client"
 	"github.com/qernal/cli-qernal/pkg/common"
+	"github.com/qernal/cli-qernal/pkg/helpers"
 	"github.com/qernal/cli-qernal/pkg/utils"
 	openapi_chaos_client "github.com/qernal/openapi-chaos-go-client"
 	"github.com/spf13/cobra"
@@ -24,7 +25,7 @@ func NewUpdateCmd(printer *utils.Printer) *cobra.Command {
 			ctx := context.Background()
 			token, err := auth.GetQernalToken()
 			if err != nil {
-				return charm.RenderError("unable to retreive qernal token, run qernal auth login if you haven't")
+				return charm.RenderError("unable to retreive qernal token, run qernal auth login if you haven't", err)
 
 			}
 
@@ -35,18 +36,24 @@ func NewUpdateCmd(printer *utils.Printer) *cobra.Command {
 			orgName, _ := cmd.Flags().GetString("organisation")
 			orgID, _ := cmd.Flags().GetString("organisation-id")
 
-			patchResp, httpRes, err := qc.OrganisationsAPI.OrganisationsUpdate(ctx, orgID).OrganisationBody(openapi_chaos_client.OrganisationBody{
+			body := openapi_chaos_client.OrganisationBody{
 				Name: orgName,
-			}).Execute()
+			}
+			if helpers.DryRun(cmd) {
+				return helpers.PrintDryRun(printer, helpers.DryRunRequest{Method: http.MethodPut, Path: "/organisations/" + orgID, Body: body})
+			}
+
+			patchResp, httpRes, err := qc.OrganisationsAPI.OrganisationsUpdate(ctx, orgID).OrganisationBody(body).Execute()
 			if err != nil {
 				resData, _ := client.ParseResponseData(httpRes)
 				if data, ok := resData.(map[string]interface{}); ok {
 					if innerData, ok := data["data"].(map[string]interface{}); ok {
 						if nameErr, ok := innerData["name"].(string); ok {
-							return printer.RenderError("unable to create organisation", errors.New(nameErr))
+							return printer.RenderError("unable to update organisation", client.NewResponseError(nameErr, err))
 						}
 					}
 				}
+				return printer.RenderError("unable to update organisation", err)
 			}
 
 			var data interface{}
@@ -63,6 +70,7 @@ func NewUpdateCmd(printer *utils.Printer) *cobra.Command {
 		},
 	}
 	cmd.Flags().StringVarP(&common.OutputFormat, "output", "o", "text", "output format (json,text)")
+	helpers.AddDryRunFlag(cmd)
 	_ = cmd.MarkFlagRequired("organisation")
 	_ = cmd.MarkFlagRequired("organisation-id")
 	return cmd
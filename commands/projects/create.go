@@ -2,11 +2,13 @@ package projects
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/qernal/cli-qernal/charm"
 	"github.com/qernal/cli-qernal/commands/auth"
 	"github.com/qernal/cli-qernal/pkg/client"
 	"github.com/qernal/cli-qernal/pkg/common"
+	"github.com/qernal/cli-qernal/pkg/helpers"
 	"github.com/qernal/cli-qernal/pkg/utils"
 	openapi_chaos_client "github.com/qernal/openapi-chaos-go-client"
 	"github.com/spf13/cobra"
@@ -26,7 +28,7 @@ func NewCreateCmd(printer *utils.Printer) *cobra.Command {
 			ctx := context.Background()
 			token, err := auth.GetQernalToken()
 			if err != nil {
-				return charm.RenderError("unable to retreive qernal token, run qernal auth login if you haven't")
+				return charm.RenderError("unable to retreive qernal token, run qernal auth login if you haven't", err)
 
 			}
 			qc, err := client.New(ctx, nil, nil, token)
@@ -35,14 +37,25 @@ func NewCreateCmd(printer *utils.Printer) *cobra.Command {
 			}
 
 			orgID, _ := cmd.Flags().GetString("organisation-id")
-			project, _, err := qc.ProjectsAPI.ProjectsCreate(ctx).ProjectBody(openapi_chaos_client.ProjectBody{
+			body := openapi_chaos_client.ProjectBody{
 				OrgId: orgID,
 				Name:  projectName,
-			}).Execute()
+			}
+			if helpers.DryRun(cmd) {
+				return helpers.PrintDryRun(printer, helpers.DryRunRequest{Method: http.MethodPost, Path: "/projects", Body: body})
+			}
+
+			project, _, err := qc.ProjectsAPI.ProjectsCreate(ctx).ProjectBody(body).Execute()
 			if err != nil {
 				return charm.RenderError("unable to create project", err)
 
 			}
+
+			if common.Quiet {
+				printer.PrintIDs(project.Id)
+				return nil
+			}
+
 			var data interface{}
 
 			if common.OutputFormat == "json" {
@@ -64,7 +77,9 @@ func NewCreateCmd(printer *utils.Printer) *cobra.Command {
 	}
 	cmd.Flags().StringVarP(&projectName, "name", "n", "", "Name of the project")
 	cmd.Flags().StringVarP(&common.OutputFormat, "output", "o", "text", "output format (json,text)")
+	helpers.AddDryRunFlag(cmd)
 	_ = cmd.MarkFlagRequired("organisation-id")
 	_ = cmd.MarkFlagRequired("name")
+	utils.AddQuietFlag(cmd)
 	return cmd
 }
@@ -3,10 +3,12 @@ package projects
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	"github.com/qernal/cli-qernal/charm"
 	"github.com/qernal/cli-qernal/commands/auth"
 	"github.com/qernal/cli-qernal/pkg/client"
+	"github.com/qernal/cli-qernal/pkg/helpers"
 	"github.com/qernal/cli-qernal/pkg/utils"
 	"github.com/spf13/cobra"
 )
@@ -19,13 +21,13 @@ func NewDeleteCmd(printer *utils.Printer) *cobra.Command {
 		Example: "qernal projects delete --project <project ID>",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) > 0 {
-				return charm.RenderError("No arguments expected")
+				return utils.UsageError("no arguments expected")
 			}
 
 			ctx := context.Background()
 			token, err := auth.GetQernalToken()
 			if err != nil {
-				return charm.RenderError("unable to retreive qernal token, run qernal auth login if you haven't")
+				return charm.RenderError("unable to retreive qernal token, run qernal auth login if you haven't", err)
 
 			}
 
@@ -47,6 +49,9 @@ func NewDeleteCmd(printer *utils.Printer) *cobra.Command {
 			}
 
 			project := projects.Data[0]
+			if helpers.DryRun(cmd) {
+				return helpers.PrintDryRun(printer, helpers.DryRunRequest{Method: http.MethodDelete, Path: "/projects/" + project.Id})
+			}
 			_, _, err = qc.ProjectsAPI.ProjectsDelete(ctx, project.Id).Execute()
 			if err != nil {
 				return charm.RenderError("error deleting qernal project", err)
@@ -58,6 +63,7 @@ func NewDeleteCmd(printer *utils.Printer) *cobra.Command {
 		},
 	}
 	cmd.Flags().StringVarP(&projectId, "project", "p", "", "name of the project")
+	helpers.AddDryRunFlag(cmd)
 	_ = cmd.MarkFlagRequired("project")
 	return cmd
 }
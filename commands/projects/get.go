@@ -18,13 +18,13 @@ func NewGetCmd(printer *utils.Printer) *cobra.Command {
 		Example: "qernal project get --name <project name>",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) > 0 {
-				return charm.RenderError("No arguments expected")
+				return utils.UsageError("no arguments expected")
 			}
 
 			ctx := context.Background()
 			token, err := auth.GetQernalToken()
 			if err != nil {
-				return charm.RenderError("unable to retreive qernal token, run qernal auth login if you haven't")
+				return charm.RenderError("unable to retreive qernal token, run qernal auth login if you haven't", err)
 
 			}
 
@@ -35,36 +35,38 @@ func NewGetCmd(printer *utils.Printer) *cobra.Command {
 
 			name, _ := cmd.Flags().GetString("name")
 
-			project, err := qc.GetProjectByName(name)
-			if err != nil {
-				return charm.RenderError("", err)
-			}
+			return printer.Watch(func() error {
+				project, err := qc.GetProjectByName(name)
+				if err != nil {
+					return charm.RenderError("", err)
+				}
 
-			var data interface{}
+				var data interface{}
 
-			if common.OutputFormat == "json" {
-				data = project
-			} else {
-				data = map[string]interface{}{
-					"Name":       project.Name,
-					"Project ID": project.Id,
-					"Org ID":     project.OrgId,
+				if common.OutputFormat == "json" {
+					data = project
+				} else {
+					data = map[string]interface{}{
+						"Name":       project.Name,
+						"Project ID": project.Id,
+						"Org ID":     project.OrgId,
+					}
 				}
-			}
 
-			if common.OutputFormat == "json" {
-				printer.PrintResource(utils.FormatOutput(data, common.OutputFormat))
-				return nil
-			}
-
-			response := charm.SuccessStyle.Render(utils.FormatOutput(data, common.OutputFormat))
-			printer.PrintResource(response)
-			return nil
+				if common.OutputFormat == "json" {
+					printer.PrintResource(utils.FormatOutput(data, common.OutputFormat))
+					return nil
+				}
 
+				response := charm.SuccessStyle.Render(utils.FormatOutput(data, common.OutputFormat))
+				printer.PrintResource(response)
+				return nil
+			})
 		},
 	}
 	cmd.Flags().StringVarP(&name, "name", "n", "", "name of the project")
 	cmd.Flags().StringVarP(&common.OutputFormat, "output", "o", "text", "output format (json,text)")
 	_ = cmd.MarkFlagRequired("name")
+	utils.AddWatchFlags(cmd)
 	return cmd
 }
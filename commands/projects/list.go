@@ -2,7 +2,6 @@ package projects
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/qernal/cli-qernal/charm"
 	"github.com/qernal/cli-qernal/commands/auth"
@@ -23,7 +22,7 @@ func NewProjectsListCmd(printer *utils.Printer) *cobra.Command {
 
 			token, err := auth.GetQernalToken()
 			if err != nil {
-				return charm.RenderError("unable to retrieive qernal token, run qernal auth login if you haven't")
+				return charm.RenderError("unable to retrieive qernal token, run qernal auth login if you haven't", err)
 			}
 			ctx := context.Background()
 			qc, err := client.New(ctx, nil, nil, token)
@@ -33,23 +32,34 @@ func NewProjectsListCmd(printer *utils.Printer) *cobra.Command {
 
 			maxResults, _ := cmd.Flags().GetInt32("max")
 
-			allProjects, err := helpers.PaginateProjects(printer, ctx, &qc, maxResults)
-			if err != nil {
-				return charm.RenderError("unable to list projects", err)
-			}
-			if maxResults > 0 && len(allProjects) > int(maxResults) {
-				allProjects = allProjects[:maxResults]
-			}
+			return printer.Watch(func() error {
+				allProjects, err := helpers.PaginateProjects(printer, ctx, &qc, maxResults)
+				if err != nil {
+					return charm.RenderError("unable to list projects", err)
+				}
+				if maxResults > 0 && len(allProjects) > int(maxResults) {
+					allProjects = allProjects[:maxResults]
+				}
 
-			if common.OutputFormat == "json" {
-				fmt.Println(utils.FormatOutput(allProjects, common.OutputFormat))
-				return nil
-			}
-			table := charm.RenderProjectTable(allProjects)
-			fmt.Println(table)
+				if common.Quiet {
+					for _, project := range allProjects {
+						printer.PrintIDs(project.Id)
+					}
+					return nil
+				}
+
+				if common.OutputFormat == "json" {
+					printer.PrintPaged(utils.FormatOutput(allProjects, common.OutputFormat))
+					return nil
+				}
+				table := charm.RenderProjectTable(allProjects)
+				printer.PrintPaged(table)
 
-			return nil
+				return nil
+			})
 		},
 	}
+	utils.AddQuietFlag(cmd)
+	utils.AddWatchFlags(cmd)
 	return cmd
 }
@@ -3,11 +3,13 @@ package projects
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	"github.com/qernal/cli-qernal/charm"
 	"github.com/qernal/cli-qernal/commands/auth"
 	"github.com/qernal/cli-qernal/pkg/client"
 	"github.com/qernal/cli-qernal/pkg/common"
+	"github.com/qernal/cli-qernal/pkg/helpers"
 	"github.com/qernal/cli-qernal/pkg/utils"
 	openapi_chaos_client "github.com/qernal/openapi-chaos-go-client"
 	"github.com/spf13/cobra"
@@ -28,7 +30,7 @@ func NewUpdateCmd(printer *utils.Printer) *cobra.Command {
 			ctx := context.Background()
 			token, err := auth.GetQernalToken()
 			if err != nil {
-				return charm.RenderError("unable to retreive qernal token, run qernal auth login if you haven't")
+				return charm.RenderError("unable to retreive qernal token, run qernal auth login if you haven't", err)
 
 			}
 
@@ -39,10 +41,15 @@ func NewUpdateCmd(printer *utils.Printer) *cobra.Command {
 
 			orgID, _ := cmd.Flags().GetString("organisation-id")
 
-			patchResp, _, err := qc.ProjectsAPI.ProjectsUpdate(ctx, projectId).ProjectBodyPatch(openapi_chaos_client.ProjectBodyPatch{
+			body := openapi_chaos_client.ProjectBodyPatch{
 				OrgId: &orgID,
 				Name:  &name,
-			}).Execute()
+			}
+			if helpers.DryRun(cmd) {
+				return helpers.PrintDryRun(printer, helpers.DryRunRequest{Method: http.MethodPut, Path: "/projects/" + projectId, Body: body})
+			}
+
+			patchResp, _, err := qc.ProjectsAPI.ProjectsUpdate(ctx, projectId).ProjectBodyPatch(body).Execute()
 			if err != nil {
 				return charm.RenderError(fmt.Sprintf("unable to update project, patch failed with: %s", err))
 			}
@@ -56,6 +63,7 @@ func NewUpdateCmd(printer *utils.Printer) *cobra.Command {
 	cmd.Flags().StringVarP(&projectId, "project", "p", "", "Project ID")
 	cmd.Flags().StringVarP(&name, "name", "n", "", "name of the project to be updated")
 	cmd.Flags().StringVarP(&common.OutputFormat, "output", "o", "text", "output format (json,text)")
+	helpers.AddDryRunFlag(cmd)
 	_ = cmd.MarkFlagRequired("organisation-id")
 	_ = cmd.MarkFlagRequired("name")
 	return cmd
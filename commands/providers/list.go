@@ -2,7 +2,6 @@ package providers
 
 import (
 	"context"
-	"errors"
 
 	"github.com/qernal/cli-qernal/pkg/utils"
 	"github.com/spf13/cobra"
@@ -22,35 +21,47 @@ func NewListCmd(printer *utils.Printer) *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			token, err := auth.GetQernalToken()
 			if err != nil {
-				return charm.RenderError("unable to retrieive qernal token, run qernal auth login if you haven't")
+				return charm.RenderError("unable to retrieive qernal token, run qernal auth login if you haven't", err)
 			}
 			ctx := context.Background()
 			qc, err := client.New(ctx, nil, nil, token)
 			if err != nil {
 				return charm.RenderError("", err)
 			}
-			providerResp, httpRes, err := qc.ProvidersAPI.ProvidersList(ctx).Execute()
-			if err != nil {
-				resData, _ := client.ParseResponseData(httpRes)
-				if data, ok := resData.(map[string]interface{}); ok {
-					if innerData, ok := data["data"].(map[string]interface{}); ok {
-						if nameErr, ok := innerData["name"].(string); ok {
-							return printer.RenderError("unable to list organisations", errors.New(nameErr))
+
+			return printer.Watch(func() error {
+				providerResp, httpRes, err := qc.ProvidersAPI.ProvidersList(ctx).Execute()
+				if err != nil {
+					resData, _ := client.ParseResponseData(httpRes)
+					if data, ok := resData.(map[string]interface{}); ok {
+						if innerData, ok := data["data"].(map[string]interface{}); ok {
+							if nameErr, ok := innerData["name"].(string); ok {
+								return printer.RenderError("unable to list organisations", client.NewResponseError(nameErr, err))
+							}
 						}
 					}
+					return charm.RenderError("unable to list providers", err)
 				}
-				return charm.RenderError("unable to list providers", err)
-			}
 
-			if common.OutputFormat == "json" {
-				printer.PrintResource(utils.FormatOutput(providerResp, common.OutputFormat))
-				return nil
-			}
+				if common.Quiet {
+					for _, provider := range providerResp.Data {
+						printer.PrintIDs(provider.Id)
+					}
+					return nil
+				}
 
-			table := charm.RenderProviderTable(providerResp.Data)
-			printer.PrintResource(table)
-			return nil
+				if common.OutputFormat == "json" {
+					printer.PrintResource(utils.FormatOutput(providerResp, common.OutputFormat))
+					return nil
+				}
+
+				table := charm.RenderProviderTable(providerResp.Data)
+				printer.PrintResource(table)
+				return nil
+			})
 		},
 	}
+	utils.AddQuietFlag(&cmd)
+	utils.AddWatchFlags(&cmd)
 	return &cmd
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/qernal/cli-qernal/charm"
 	"github.com/qernal/cli-qernal/commands/auth"
 	"github.com/qernal/cli-qernal/commands/functions"
 	"github.com/qernal/cli-qernal/commands/hosts"
@@ -14,6 +15,7 @@ import (
 	"github.com/qernal/cli-qernal/commands/secrets"
 	"github.com/qernal/cli-qernal/pkg/build"
 	"github.com/qernal/cli-qernal/pkg/common"
+	"github.com/qernal/cli-qernal/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -26,9 +28,27 @@ var (
 	orgName    string
 )
 var RootCmd = &cobra.Command{
-	Use:          "qernal",
-	Short:        fmt.Sprintf("CLI for interacting with Qernal\nVersion: %s", build.Version),
-	SilenceUsage: true,
+	Use:   "qernal",
+	Short: fmt.Sprintf("CLI for interacting with Qernal\nVersion: %s", build.Version),
+	Long: fmt.Sprintf(`CLI for interacting with Qernal
+Version: %s
+
+Exit codes:
+  0    success
+  1    unclassified error
+  2    usage error, invalid flags, arguments or input
+  3    authentication failure
+  4    resource not found
+  5    conflict, the resource already exists or was modified concurrently
+  6    API or server error
+  7    timeout
+  8    partial failure, some operations in a batch failed
+  9    drift, live functions differ from their definition (functions diff)
+  130  interrupted
+
+Errors are written to stderr, with -o json they are written as {"error": {"code", "type", "message"}}.`, build.Version),
+	SilenceUsage:  true,
+	SilenceErrors: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if version {
 			versionCmd.Run(cmd, args)
@@ -43,14 +63,40 @@ var RootCmd = &cobra.Command{
 
 func Execute() {
 	err := RootCmd.Execute()
+	if err == nil {
+		return
+	}
+
+	err = utils.CommandError(err)
+	printer := utils.NewPrinter()
+	printer.PrintError(err)
+	os.Exit(utils.ExitCode(err))
+}
+
+// applyTheme sets the colour theme from QERNAL_THEME or the theme and palette keys of the config file
+func applyTheme() {
+	cfg, _ := auth.ReadConfig()
+	name := cfg.Theme
+	if env, ok := os.LookupEnv("QERNAL_THEME"); ok {
+		name = env
+	}
+
+	theme, err := charm.LoadTheme(name, cfg.Palette)
 	if err != nil {
-		os.Exit(1)
+		utils.NewPrinter().PrintWarning(fmt.Sprintf("ignoring theme config, %s", err.Error()))
+		theme, _ = charm.LoadTheme(charm.ThemeAuto, nil)
 	}
+	charm.SetTheme(theme)
 }
 
 func init() {
+	cobra.OnInitialize(applyTheme)
+	RootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return utils.UsageError("", err)
+	})
 	RootCmd.Flags().BoolVarP(&version, "version", "v", false, "Print the version of the CLI")
 	RootCmd.PersistentFlags().StringVarP(&common.OutputFormat, "output", "o", "text", "output format (json,text)")
+	RootCmd.PersistentFlags().BoolVar(&common.NoPager, "no-pager", false, "don't pipe long output through $QERNAL_PAGER or $PAGER")
 	RootCmd.PersistentFlags().Int32Var(&maxResults, "max", 0, "Maximum number of results to return, defaults to all")
 	RootCmd.PersistentFlags().StringVar(&projectID, "project-id", "", "ID of the project")
 	RootCmd.PersistentFlags().StringVar(&project, "project", "", "name of the project")
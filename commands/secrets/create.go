@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"strings"
 
@@ -46,7 +47,7 @@ The secret value is read from stdin, allowing for secure input methods.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 
 			if len(args) > 0 && secretType != "certificate" {
-				return charm.RenderError("No arguments expected. Please provide input through stdin.")
+				return utils.UsageError("no arguments expected. Please provide input through stdin.")
 			}
 
 			ctx := context.Background()
@@ -54,7 +55,7 @@ The secret value is read from stdin, allowing for secure input methods.`,
 			token, err := auth.GetQernalToken()
 
 			if err != nil {
-				return charm.RenderError("unable to retrieve qernal token, run qernal auth login if you haven't")
+				return charm.RenderError("unable to retrieve qernal token, run qernal auth login if you haven't", err)
 
 			}
 			qc, err := client.New(ctx, nil, nil, token)
@@ -72,6 +73,9 @@ The secret value is read from stdin, allowing for secure input methods.`,
 			if err != nil {
 				return charm.RenderError("unable to fetch dek key", err)
 			}
+			var body openapi_chaos_client.SecretBody
+			var created string
+			encryptionRef := fmt.Sprintf(`keys/dek/%d`, dek.Revision)
 			switch secretType {
 			case "registry":
 				// mark registry flag required
@@ -91,8 +95,7 @@ The secret value is read from stdin, allowing for secure input methods.`,
 					return charm.RenderError("unable to  encrypt input", err)
 
 				}
-				encryptionRef := fmt.Sprintf(`keys/dek/%d`, dek.Revision)
-				_, _, err = qc.SecretsAPI.ProjectsSecretsCreate(ctx, projectID).SecretBody(openapi_chaos_client.SecretBody{
+				body = openapi_chaos_client.SecretBody{
 					Name:       strings.ToUpper(name),
 					Encryption: encryptionRef,
 					Type:       openapi_chaos_client.SECRETCREATETYPE_REGISTRY,
@@ -102,13 +105,8 @@ The secret value is read from stdin, allowing for secure input methods.`,
 							RegistryValue: encryptedValue,
 						},
 					},
-				}).Execute()
-				if err != nil {
-					return charm.RenderError("unable to  create registry secret", err)
-
 				}
-				printer.PrintResource(charm.SuccessStyle.Render("created registry secret with name " + secretName))
-				return nil
+				created = "created registry secret with name " + secretName
 			case "environment":
 				// Read from stdin
 				reader := bufio.NewReader(cmd.InOrStdin())
@@ -123,8 +121,7 @@ The secret value is read from stdin, allowing for secure input methods.`,
 					return charm.RenderError("unable to  encrypt input", err)
 				}
 
-				encryptionRef := fmt.Sprintf(`keys/dek/%d`, dek.Revision)
-				_, _, err = qc.SecretsAPI.ProjectsSecretsCreate(ctx, projectID).SecretBody(openapi_chaos_client.SecretBody{
+				body = openapi_chaos_client.SecretBody{
 					Name:       strings.ToUpper(secretName),
 					Encryption: encryptionRef,
 					Type:       openapi_chaos_client.SECRETCREATETYPE_ENVIRONMENT,
@@ -133,11 +130,8 @@ The secret value is read from stdin, allowing for secure input methods.`,
 							EnvironmentValue: encryptedValue,
 						},
 					},
-				}).Execute()
-				if err != nil {
-					return charm.RenderError("unable to create environment secret", err)
 				}
-				printer.PrintResource(charm.SuccessStyle.Render("created environment secret with name " + secretName))
+				created = "created environment secret with name " + secretName
 			case "certificate":
 
 				if publicKey == "" || privateKey == "" {
@@ -159,8 +153,7 @@ The secret value is read from stdin, allowing for secure input methods.`,
 					return charm.RenderError("unable to private key", err)
 				}
 
-				encryptionRef := fmt.Sprintf(`keys/dek/%d`, dek.Revision)
-				_, _, err = qc.SecretsAPI.ProjectsSecretsCreate(ctx, projectID).SecretBody(openapi_chaos_client.SecretBody{
+				body = openapi_chaos_client.SecretBody{
 					Name:       strings.ToUpper(secretName),
 					Encryption: encryptionRef,
 					Type:       openapi_chaos_client.SECRETCREATETYPE_CERTIFICATE,
@@ -170,15 +163,29 @@ The secret value is read from stdin, allowing for secure input methods.`,
 							CertificateValue: privateKeyEncrypted,
 						},
 					},
-				}).Execute()
-				if err != nil {
-					return charm.RenderError("Unable to create certificate secret", err)
 				}
-				printer.PrintResource(charm.SuccessStyle.Render("Created certificate secret with name " + secretName))
-				return nil
+				created = "Created certificate secret with name " + secretName
 			default:
 				return charm.RenderError("Invalid secret type. Must be on of 'registry', 'environment', or 'certificate'")
 			}
+
+			if helpers.DryRun(cmd) {
+				return helpers.PrintDryRun(printer, helpers.DryRunRequest{
+					Method: http.MethodPost,
+					Path:   fmt.Sprintf("/projects/%s/secrets", projectID),
+					Body:   redactSecretBody(body),
+				})
+			}
+
+			secret, _, err := qc.SecretsAPI.ProjectsSecretsCreate(ctx, projectID).SecretBody(body).Execute()
+			if err != nil {
+				return charm.RenderError(fmt.Sprintf("unable to create %s secret", secretType), err)
+			}
+			if common.Quiet {
+				printer.PrintIDs(secret.Name)
+				return nil
+			}
+			printer.PrintResource(charm.SuccessStyle.Render(created))
 			return nil
 		},
 	}
@@ -190,9 +197,34 @@ The secret value is read from stdin, allowing for secure input methods.`,
 	cmd.Flags().StringVarP(&publicKey, "public-key", "", "", "File path to the public key for certificate type")
 	cmd.Flags().StringVarP(&privateKey, "private-key", "", "", "File path to the private key for certificate type")
 
+	helpers.AddDryRunFlag(cmd)
+
 	_ = cmd.MarkFlagRequired("name")
 
 	_ = cmd.MarkFlagRequired("type")
+	utils.AddQuietFlag(cmd)
 
 	return cmd
 }
+
+// redactSecretBody returns body with its encrypted values replaced by a placeholder
+func redactSecretBody(body openapi_chaos_client.SecretBody) openapi_chaos_client.SecretBody {
+	payload := body.Payload
+	if payload.SecretRegistry != nil {
+		registry := *payload.SecretRegistry
+		registry.RegistryValue = helpers.EncryptedPlaceholder
+		payload.SecretRegistry = &registry
+	}
+	if payload.SecretEnvironment != nil {
+		environment := *payload.SecretEnvironment
+		environment.EnvironmentValue = helpers.EncryptedPlaceholder
+		payload.SecretEnvironment = &environment
+	}
+	if payload.SecretCertificate != nil {
+		certificate := *payload.SecretCertificate
+		certificate.CertificateValue = helpers.EncryptedPlaceholder
+		payload.SecretCertificate = &certificate
+	}
+	body.Payload = payload
+	return body
+}
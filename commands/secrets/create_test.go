@@ -11,6 +11,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/qernal/cli-qernal/pkg/helpers"
 	"github.com/qernal/cli-qernal/pkg/utils"
+	openapi_chaos_client "github.com/qernal/openapi-chaos-go-client"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -165,3 +166,16 @@ func TestCertCreate(t *testing.T) {
 		os.Remove(keyFilePath)
 	})
 }
+
+func TestRedactSecretBody(t *testing.T) {
+	body := openapi_chaos_client.SecretBody{
+		Name: "DATABASE_URL",
+		Payload: openapi_chaos_client.SecretCreatePayload{
+			SecretEnvironment: &openapi_chaos_client.SecretEnvironment{EnvironmentValue: "ciphertext"},
+		},
+	}
+
+	redacted := redactSecretBody(body)
+	assert.Equal(t, helpers.EncryptedPlaceholder, redacted.Payload.SecretEnvironment.EnvironmentValue)
+	assert.Equal(t, "ciphertext", body.Payload.SecretEnvironment.EnvironmentValue, "the body that is sent is left alone")
+}
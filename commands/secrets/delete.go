@@ -2,6 +2,8 @@ package secrets
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 
 	"github.com/qernal/cli-qernal/charm"
 	"github.com/qernal/cli-qernal/commands/auth"
@@ -22,7 +24,7 @@ func NewDeleteCmd(printer *utils.Printer) *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			token, err := auth.GetQernalToken()
 			if err != nil {
-				return charm.RenderError("unable to retreive qernal token, run qernal auth login if you haven't")
+				return charm.RenderError("unable to retreive qernal token, run qernal auth login if you haven't", err)
 			}
 			ctx := context.Background()
 			qc, err := client.New(ctx, nil, nil, token)
@@ -35,6 +37,13 @@ func NewDeleteCmd(printer *utils.Printer) *cobra.Command {
 				return err
 			}
 
+			if helpers.DryRun(cmd) {
+				return helpers.PrintDryRun(printer, helpers.DryRunRequest{
+					Method: http.MethodDelete,
+					Path:   fmt.Sprintf("/projects/%s/secrets/%s", projectID, secretName),
+				})
+			}
+
 			_, _, err = qc.SecretsAPI.ProjectsSecretsDelete(ctx, projectID, secretName).Execute()
 			if err != nil {
 				return charm.RenderError("unable to delete secret,  request failed with:", err)
@@ -46,6 +55,7 @@ func NewDeleteCmd(printer *utils.Printer) *cobra.Command {
 		},
 	}
 	cmd.Flags().StringVarP(&secretName, "name", "n", "", "name of the secret")
+	helpers.AddDryRunFlag(cmd)
 	_ = cmd.MarkFlagRequired("project")
 	_ = cmd.MarkFlagRequired("name")
 	return cmd
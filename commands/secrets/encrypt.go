@@ -29,7 +29,7 @@ func NewEncryptCmd(printer *utils.Printer) *cobra.Command {
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) > 0 {
-				return charm.RenderError("No arguments expected. Please provide input through stdin.")
+				return utils.UsageError("no arguments expected. Please provide input through stdin.")
 			}
 
 			// Read from stdin
@@ -59,7 +59,7 @@ func NewEncryptCmd(printer *utils.Printer) *cobra.Command {
 			token, err := auth.GetQernalToken()
 
 			if err != nil {
-				return charm.RenderError("unable to retreive qernal token, run qernal auth login if you haven't")
+				return charm.RenderError("unable to retreive qernal token, run qernal auth login if you haven't", err)
 
 			}
 
@@ -23,13 +23,13 @@ func NewGetCmd(printer *utils.Printer) *cobra.Command {
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) > 0 {
-				return charm.RenderError("No arguments expected")
+				return utils.UsageError("no arguments expected")
 			}
 
 			ctx := context.Background()
 			token, err := auth.GetQernalToken()
 			if err != nil {
-				return charm.RenderError("unable to retreive qernal token, run qernal auth login if you haven't")
+				return charm.RenderError("unable to retreive qernal token, run qernal auth login if you haven't", err)
 
 			}
 
@@ -44,33 +44,35 @@ func NewGetCmd(printer *utils.Printer) *cobra.Command {
 				return err
 			}
 
-			secret, err := qc.GetSecretByName(secretName, projectID)
-			if err != nil {
-				return printer.RenderError("x", err)
-			}
-
-			var data interface{}
-			if common.OutputFormat == "json" {
-				data = secret
-			} else {
-				data = getSecretData(secret)
-			}
-
-			if common.OutputFormat == "json" {
-				printer.PrintResource(utils.FormatOutput(data, common.OutputFormat))
+			return printer.Watch(func() error {
+				secret, err := qc.GetSecretByName(secretName, projectID)
+				if err != nil {
+					return printer.RenderError("x", err)
+				}
+
+				var data interface{}
+				if common.OutputFormat == "json" {
+					data = secret
+				} else {
+					data = getSecretData(secret)
+				}
+
+				if common.OutputFormat == "json" {
+					printer.PrintResource(utils.FormatOutput(data, common.OutputFormat))
+					return nil
+				}
+
+				response := charm.SuccessStyle.Render(utils.FormatOutput(data, common.OutputFormat))
+				printer.PrintResource(response)
 				return nil
-			}
-
-			response := charm.SuccessStyle.Render(utils.FormatOutput(data, common.OutputFormat))
-			printer.PrintResource(response)
-			return nil
-
+			})
 		},
 	}
 	cmd.Flags().StringVar(&secretName, "name", "", "name of the secret")
 	cmd.Flags().StringVarP(&common.OutputFormat, "output", "o", "text", "output format (json,text)")
 	_ = cmd.MarkFlagRequired("name")
 	_ = cmd.MarkFlagRequired("project")
+	utils.AddWatchFlags(cmd)
 	return cmd
 }
 
@@ -2,7 +2,6 @@ package secrets
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/qernal/cli-qernal/charm"
 	"github.com/qernal/cli-qernal/commands/auth"
@@ -25,7 +24,7 @@ func NewSecretsListCmd(printer *utils.Printer) *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			token, err := auth.GetQernalToken()
 			if err != nil {
-				return charm.RenderError("unable to retreive qernal token, run qernal auth login if you haven't")
+				return charm.RenderError("unable to retreive qernal token, run qernal auth login if you haven't", err)
 			}
 			ctx := context.Background()
 			qc, err := client.New(ctx, nil, nil, token)
@@ -37,23 +36,36 @@ func NewSecretsListCmd(printer *utils.Printer) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			secrets, err := helpers.PaginateSecrets(printer, ctx, &qc, maxResults, projectID)
-			if err != nil {
-				return charm.RenderError("unable to list secrets", err)
-			}
-			if maxResults > 0 && len(secrets) > int(maxResults) {
-				secrets = secrets[:maxResults]
-			}
 
-			if common.OutputFormat == "json" {
-				fmt.Println(utils.FormatOutput(secrets, common.OutputFormat))
+			return printer.Watch(func() error {
+				secrets, err := helpers.PaginateSecrets(printer, ctx, &qc, maxResults, projectID)
+				if err != nil {
+					return charm.RenderError("unable to list secrets", err)
+				}
+				if maxResults > 0 && len(secrets) > int(maxResults) {
+					secrets = secrets[:maxResults]
+				}
+
+				// secrets are addressed by name, there is no separate ID
+				if common.Quiet {
+					for _, secret := range secrets {
+						printer.PrintIDs(secret.Name)
+					}
+					return nil
+				}
+
+				if common.OutputFormat == "json" {
+					printer.PrintPaged(utils.FormatOutput(secrets, common.OutputFormat))
+					return nil
+				}
+				table := charm.RenderSecretsTable(secrets)
+				printer.PrintPaged(table)
 				return nil
-			}
-			table := charm.RenderSecretsTable(secrets)
-			fmt.Println(table)
-			return nil
+			})
 		},
 	}
 	_ = cmd.MarkFlagRequired("project")
+	utils.AddQuietFlag(cmd)
+	utils.AddWatchFlags(cmd)
 	return cmd
 }
@@ -5,6 +5,7 @@ import (
 	"crypto/rand"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -13,9 +14,11 @@ import (
 
 	"github.com/joho/godotenv"
 	"github.com/qernal/cli-qernal/pkg/oauth"
+	"github.com/qernal/cli-qernal/pkg/utils"
 
 	openapiclient "github.com/qernal/openapi-chaos-go-client"
 	"golang.org/x/crypto/nacl/box"
+	"golang.org/x/oauth2"
 )
 
 type QernalAPIClient struct {
@@ -41,11 +44,16 @@ func New(ctx context.Context, hostHydra, hostChaos *string, token string) (clien
 	oauthClient := oauth.NewOauthClient(hydra)
 	err = oauthClient.ExtractClientIDAndClientSecretFromToken(token)
 	if err != nil {
-		return QernalAPIClient{}, err
+		return QernalAPIClient{}, utils.NewError(utils.ExitAuth, "", err)
 	}
 
 	accessToken, err := oauthClient.GetAccessTokenWithClientCredentials()
 	if err != nil {
+		// only a rejected token is an auth failure, network errors are classified by their cause
+		var retrieveErr *oauth2.RetrieveError
+		if errors.As(err, &retrieveErr) {
+			return QernalAPIClient{}, utils.NewError(utils.ExitAuth, "unable to obtain access token", err)
+		}
 		return QernalAPIClient{}, err
 	}
 
@@ -69,11 +77,10 @@ func New(ctx context.Context, hostHydra, hostChaos *string, token string) (clien
 // FetchDek retrieves the DEK for a given project by its project ID.
 func (qc *QernalAPIClient) FetchDek(ctx context.Context, projectID string) (*openapiclient.SecretMetaResponse, error) {
 	keyRes, httpres, err := qc.SecretsAPI.ProjectsSecretsGet(ctx, projectID, "dek").Execute()
-	slog.Info(httpres.Status)
 	if err != nil {
 		resData, httperr := ParseResponseData(httpres)
 		if httperr != nil {
-			return nil, fmt.Errorf("failed to fetch DEK key: unexpected HTTP error: %w", httperr)
+			return nil, fmt.Errorf("failed to fetch DEK key: unexpected HTTP error: %w", err)
 		}
 		return nil, fmt.Errorf("failed to fetch DEK key: unexpected error: %w, detail: %v", err, resData)
 	}
@@ -85,17 +92,20 @@ func (qc *QernalAPIClient) GetProjectByName(name string) (openapiclient.ProjectR
 	if err != nil {
 		resData, httperr := ParseResponseData(httpRes)
 		if httperr != nil {
-			return openapiclient.ProjectResponse{}, fmt.Errorf("failed to fetch project by name: unexpected HTTP error: %w", httperr)
+			return openapiclient.ProjectResponse{}, fmt.Errorf("failed to fetch project by name: unexpected HTTP error: %w", err)
 		}
 		return openapiclient.ProjectResponse{}, fmt.Errorf("failed to fetch project by  name: unexpected error: %w, detail: %v", err, resData)
 	}
 	if len(projectResp.Data) <= 0 {
-		return openapiclient.ProjectResponse{}, fmt.Errorf("unable to find project with name %s", name)
+		return openapiclient.ProjectResponse{}, utils.NewError(utils.ExitNotFound, fmt.Sprintf("unable to find project with name %s", name))
 	}
 	return projectResp.Data[0], nil
 }
 
 func ParseResponseData(res *http.Response) (resData interface{}, err error) {
+	if res == nil {
+		return nil, errors.New("no response received")
+	}
 	body, err := io.ReadAll(res.Body)
 	if err != nil {
 		return
@@ -138,12 +148,12 @@ func (qc *QernalAPIClient) GetOrgByName(name string) (openapiclient.Organisation
 	if err != nil {
 		resData, httperr := ParseResponseData(httpRes)
 		if httperr != nil {
-			return openapiclient.OrganisationResponse{}, fmt.Errorf("failed to fetch project by name: unexpected HTTP error: %w", httperr)
+			return openapiclient.OrganisationResponse{}, fmt.Errorf("failed to fetch project by name: unexpected HTTP error: %w", err)
 		}
 		return openapiclient.OrganisationResponse{}, fmt.Errorf("failed to fetch project by  name: unexpected error: %w, detail: %v", err, resData)
 	}
 	if len(orgResp.Data) <= 0 {
-		return openapiclient.OrganisationResponse{}, fmt.Errorf("unable to find project with name %s", name)
+		return openapiclient.OrganisationResponse{}, utils.NewError(utils.ExitNotFound, fmt.Sprintf("unable to find organisation with name %s", name))
 	}
 	return orgResp.Data[0], nil
 }
@@ -153,7 +163,7 @@ func (qc *QernalAPIClient) GetSecretByName(name, projectID string) (*openapiclie
 	if err != nil {
 		resData, httperr := ParseResponseData(httpRes)
 		if httperr != nil {
-			return &openapiclient.SecretMetaResponse{}, fmt.Errorf("failed to fetch secret by name: unexpected HTTP error: %w", httperr)
+			return &openapiclient.SecretMetaResponse{}, fmt.Errorf("failed to fetch secret by name: unexpected HTTP error: %w", err)
 		}
 		return &openapiclient.SecretMetaResponse{}, fmt.Errorf("failed to fetch secret by  name: unexpected error: %w, detail: %v", err, resData)
 	}
@@ -174,3 +184,23 @@ func GetEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// ResponseError carries the message returned in an API error response, the original
+// error is kept so the HTTP status can still be used to classify the failure
+type ResponseError struct {
+	Message string
+	Err     error
+}
+
+func (e *ResponseError) Error() string {
+	return e.Message
+}
+
+func (e *ResponseError) Unwrap() error {
+	return e.Err
+}
+
+// NewResponseError wraps err with the message returned by the API
+func NewResponseError(message string, err error) error {
+	return &ResponseError{Message: message, Err: err}
+}
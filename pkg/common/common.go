@@ -1,5 +1,14 @@
 package common
 
+import "time"
+
 var (
 	OutputFormat string
+	// Quiet limits output to the IDs of created or listed resources
+	Quiet bool
+	// Watch re-runs list and get commands every WatchInterval
+	Watch         bool
+	WatchInterval time.Duration
+	// NoPager prints long output directly instead of piping it through a pager
+	NoPager bool
 )
@@ -0,0 +1,250 @@
+package helpers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// latencyBounds are the upper bounds of the latency histogram buckets of a bench report
+var latencyBounds = []time.Duration{
+	time.Millisecond, 2 * time.Millisecond, 5 * time.Millisecond,
+	10 * time.Millisecond, 20 * time.Millisecond, 50 * time.Millisecond,
+	100 * time.Millisecond, 200 * time.Millisecond, 500 * time.Millisecond,
+	time.Second, 2 * time.Second, 5 * time.Second, 10 * time.Second,
+}
+
+// BenchOptions control the load Bench drives
+type BenchOptions struct {
+	// Rate is the number of requests started per second, 0 sends them as fast as Concurrency allows
+	Rate        int
+	Duration    time.Duration
+	Concurrency int
+}
+
+// BenchLatency summarises the latencies of the responses of a bench run
+type BenchLatency struct {
+	Min  string `json:"min"`
+	Mean string `json:"mean"`
+	P50  string `json:"p50"`
+	P90  string `json:"p90"`
+	P99  string `json:"p99"`
+	Max  string `json:"max"`
+}
+
+// LatencyBucket counts the responses that took at most UpTo, and longer than the bucket before
+type LatencyBucket struct {
+	UpTo  string `json:"up_to"`
+	Count int    `json:"count"`
+}
+
+// BenchReport is the outcome of a bench run. Requests that got no response are counted in Errors
+// by message, all others in StatusCodes.
+type BenchReport struct {
+	Requests    int             `json:"requests"`
+	Missed      int             `json:"missed"`
+	Duration    string          `json:"duration"`
+	Rate        float64         `json:"rate"`
+	Latency     BenchLatency    `json:"latency"`
+	Histogram   []LatencyBucket `json:"histogram"`
+	StatusCodes map[int]int     `json:"status_codes"`
+	Errors      map[string]int  `json:"errors,omitempty"`
+	// FunctionRequests are the requests the function's metrics report for the run, by status code
+	FunctionRequests map[string]int `json:"function_requests,omitempty"`
+	Started          time.Time      `json:"started"`
+	Finished         time.Time      `json:"finished"`
+}
+
+// benchSample is the outcome of one request of a bench run
+type benchSample struct {
+	latency time.Duration
+	status  int
+	err     error
+}
+
+// Bench sends request with httpClient for opts.Duration, at most opts.Concurrency at a time and
+// opts.Rate a second. A request is missed, not queued, when no worker is free to start it, so slow
+// responses don't hide in the latencies. Requests still running at the end are waited for.
+func Bench(ctx context.Context, httpClient *http.Client, request InvokeRequest, opts BenchOptions) *BenchReport {
+	running, stop := context.WithTimeout(ctx, opts.Duration)
+	defer stop()
+
+	var missed atomic.Int64
+	var tokens chan struct{}
+	if opts.Rate > 0 {
+		tokens = make(chan struct{})
+		go func() {
+			defer close(tokens)
+			ticker := time.NewTicker(time.Second / time.Duration(opts.Rate))
+			defer ticker.Stop()
+			for {
+				select {
+				case <-running.Done():
+					return
+				case <-ticker.C:
+					select {
+					case tokens <- struct{}{}:
+					default:
+						missed.Add(1)
+					}
+				}
+			}
+		}()
+	}
+
+	var mu sync.Mutex
+	var samples []benchSample
+	var wg sync.WaitGroup
+	started := time.Now()
+	for range opts.Concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				if tokens != nil {
+					if _, ok := <-tokens; !ok {
+						return
+					}
+				} else if running.Err() != nil {
+					return
+				}
+
+				start := time.Now()
+				status, err := benchRequest(ctx, httpClient, request)
+				sample := benchSample{latency: time.Since(start), status: status, err: err}
+
+				mu.Lock()
+				samples = append(samples, sample)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	return newBenchReport(samples, int(missed.Load()), started, time.Now())
+}
+
+// benchRequest sends request and discards the response body, it returns the response status
+func benchRequest(ctx context.Context, httpClient *http.Client, request InvokeRequest) (int, error) {
+	req, err := request.NewRequest(ctx)
+	if err != nil {
+		return 0, err
+	}
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	if _, err := io.Copy(io.Discard, res.Body); err != nil {
+		return 0, err
+	}
+	return res.StatusCode, nil
+}
+
+// newBenchReport summarises the samples of a bench run
+func newBenchReport(samples []benchSample, missed int, started time.Time, finished time.Time) *BenchReport {
+	elapsed := finished.Sub(started)
+	report := &BenchReport{
+		Requests:    len(samples),
+		Missed:      missed,
+		Duration:    elapsed.Round(time.Millisecond).String(),
+		StatusCodes: map[int]int{},
+		Started:     started,
+		Finished:    finished,
+	}
+	if elapsed > 0 {
+		report.Rate = math.Round(float64(len(samples))/elapsed.Seconds()*10) / 10
+	}
+
+	var latencies []time.Duration
+	for _, sample := range samples {
+		if sample.err != nil {
+			if report.Errors == nil {
+				report.Errors = map[string]int{}
+			}
+			report.Errors[benchErrorMessage(sample.err)]++
+			continue
+		}
+		report.StatusCodes[sample.status]++
+		latencies = append(latencies, sample.latency)
+	}
+	if len(latencies) == 0 {
+		return report
+	}
+
+	slices.Sort(latencies)
+	var total time.Duration
+	for _, latency := range latencies {
+		total += latency
+	}
+	report.Latency = BenchLatency{
+		Min:  formatLatency(latencies[0]),
+		Mean: formatLatency(total / time.Duration(len(latencies))),
+		P50:  formatLatency(Percentile(latencies, 50)),
+		P90:  formatLatency(Percentile(latencies, 90)),
+		P99:  formatLatency(Percentile(latencies, 99)),
+		Max:  formatLatency(latencies[len(latencies)-1]),
+	}
+	report.Histogram = latencyHistogram(latencies)
+	return report
+}
+
+// benchErrorMessage drops the method and url net/http adds to request errors, they are the same for
+// every request of a run
+func benchErrorMessage(err error) string {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		err = urlErr.Err
+	}
+	return err.Error()
+}
+
+// Percentile returns the nearest rank p percentile of sorted latencies
+func Percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[min(max(rank, 1), len(sorted))-1]
+}
+
+// latencyHistogram counts sorted latencies into latencyBounds, from the first to the last bucket
+// with any latencies. Latencies over the largest bound are counted in a last bucket up to +Inf.
+func latencyHistogram(sorted []time.Duration) []LatencyBucket {
+	counts := make([]int, len(latencyBounds)+1)
+	for _, latency := range sorted {
+		i, _ := slices.BinarySearch(latencyBounds, latency)
+		counts[i]++
+	}
+
+	first := slices.IndexFunc(counts, func(count int) bool { return count > 0 })
+	last := len(counts) - 1
+	for counts[last] == 0 {
+		last--
+	}
+
+	var buckets []LatencyBucket
+	for i := first; i <= last; i++ {
+		upTo := "+Inf"
+		if i < len(latencyBounds) {
+			upTo = latencyBounds[i].String()
+		}
+		buckets = append(buckets, LatencyBucket{UpTo: upTo, Count: counts[i]})
+	}
+	return buckets
+}
+
+// formatLatency rounds a latency to a readable precision
+func formatLatency(d time.Duration) string {
+	if d < time.Millisecond {
+		return d.Round(time.Microsecond).String()
+	}
+	return d.Round(100 * time.Microsecond).String()
+}
@@ -0,0 +1,105 @@
+package helpers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBench(t *testing.T) {
+	var served atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if served.Add(1)%4 == 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	request := InvokeRequest{Method: http.MethodGet, URL: server.URL + "/health"}
+	report := Bench(context.Background(), server.Client(), request, BenchOptions{Rate: 100, Duration: 300 * time.Millisecond, Concurrency: 4})
+
+	assert.Equal(t, int(served.Load()), report.Requests)
+	assert.InDelta(t, 30, report.Requests, 10, "requests are paced by the rate")
+	assert.Equal(t, report.Requests, report.StatusCodes[http.StatusOK]+report.StatusCodes[http.StatusServiceUnavailable])
+	assert.Equal(t, report.Requests/4, report.StatusCodes[http.StatusServiceUnavailable])
+	assert.Empty(t, report.Errors)
+	assert.NotEmpty(t, report.Latency.P99)
+
+	total := 0
+	for _, bucket := range report.Histogram {
+		total += bucket.Count
+	}
+	assert.Equal(t, report.Requests, total)
+}
+
+func TestBenchErrors(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	request := InvokeRequest{Method: http.MethodGet, URL: server.URL}
+	report := Bench(context.Background(), http.DefaultClient, request, BenchOptions{Duration: 50 * time.Millisecond, Concurrency: 2})
+
+	require.NotZero(t, report.Requests)
+	assert.Empty(t, report.StatusCodes)
+	assert.Empty(t, report.Histogram)
+	assert.Len(t, report.Errors, 1, "errors are grouped without the request url")
+	for message, count := range report.Errors {
+		assert.NotContains(t, message, server.URL)
+		assert.Equal(t, report.Requests, count)
+	}
+}
+
+func TestNewBenchReport(t *testing.T) {
+	var samples []benchSample
+	for i := 1; i <= 100; i++ {
+		samples = append(samples, benchSample{latency: time.Duration(i) * time.Millisecond, status: http.StatusOK})
+	}
+	samples = append(samples, benchSample{latency: 12 * time.Second, status: http.StatusGatewayTimeout})
+	samples = append(samples, benchSample{err: errors.New("connection reset by peer")})
+
+	started := time.Now()
+	report := newBenchReport(samples, 3, started, started.Add(2*time.Second))
+
+	assert.Equal(t, 102, report.Requests)
+	assert.Equal(t, 3, report.Missed)
+	assert.Equal(t, "2s", report.Duration)
+	assert.Equal(t, 51.0, report.Rate)
+	assert.Equal(t, map[int]int{http.StatusOK: 100, http.StatusGatewayTimeout: 1}, report.StatusCodes)
+	assert.Equal(t, map[string]int{"connection reset by peer": 1}, report.Errors)
+	assert.Equal(t, BenchLatency{Min: "1ms", Mean: "168.8ms", P50: "51ms", P90: "91ms", P99: "100ms", Max: "12s"}, report.Latency)
+
+	assert.Equal(t, []LatencyBucket{
+		{UpTo: "1ms", Count: 1},
+		{UpTo: "2ms", Count: 1},
+		{UpTo: "5ms", Count: 3},
+		{UpTo: "10ms", Count: 5},
+		{UpTo: "20ms", Count: 10},
+		{UpTo: "50ms", Count: 30},
+		{UpTo: "100ms", Count: 50},
+		{UpTo: "200ms", Count: 0},
+		{UpTo: "500ms", Count: 0},
+		{UpTo: "1s", Count: 0},
+		{UpTo: "2s", Count: 0},
+		{UpTo: "5s", Count: 0},
+		{UpTo: "10s", Count: 0},
+		{UpTo: "+Inf", Count: 1},
+	}, report.Histogram)
+}
+
+func TestPercentile(t *testing.T) {
+	sorted := []time.Duration{10, 20, 30, 40}
+	assert.Equal(t, time.Duration(10), Percentile(sorted, 0))
+	assert.Equal(t, time.Duration(20), Percentile(sorted, 50))
+	assert.Equal(t, time.Duration(40), Percentile(sorted, 99))
+	assert.Equal(t, time.Duration(40), Percentile(sorted, 100))
+	assert.Zero(t, Percentile(nil, 50))
+}
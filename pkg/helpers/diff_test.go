@@ -0,0 +1,55 @@
+package helpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	assert.Empty(t, UnifiedDiff("a", "b", "x\ny\n", "x\ny\n"))
+
+	from := "name: api\nimage: nginx:1.0\nport: 80\n"
+	to := "name: api\nimage: nginx:1.1\nport: 80\n"
+	expected := `--- live/api
++++ local/api
+@@ -1,3 +1,3 @@
+ name: api
+-image: nginx:1.0
++image: nginx:1.1
+ port: 80
+`
+	assert.Equal(t, expected, UnifiedDiff("live/api", "local/api", from, to))
+}
+
+func TestUnifiedDiffNewFile(t *testing.T) {
+	expected := `--- live/api
++++ local/api
+@@ -0,0 +1,2 @@
++name: api
++port: 80
+`
+	assert.Equal(t, expected, UnifiedDiff("live/api", "local/api", "", "name: api\nport: 80\n"))
+}
+
+func TestUnifiedDiffHunks(t *testing.T) {
+	from := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n"
+	to := "1\nchanged\n3\n4\n5\n6\n7\n8\n9\n10\n11\nchanged\n"
+	expected := `--- a
++++ b
+@@ -1,5 +1,5 @@
+ 1
+-2
++changed
+ 3
+ 4
+ 5
+@@ -9,4 +9,4 @@
+ 9
+ 10
+ 11
+-12
++changed
+`
+	assert.Equal(t, expected, UnifiedDiff("a", "b", from, to))
+}
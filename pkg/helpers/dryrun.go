@@ -0,0 +1,67 @@
+package helpers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/qernal/cli-qernal/pkg/common"
+	"github.com/qernal/cli-qernal/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// EncryptedPlaceholder replaces encrypted values in the requests printed by --dry-run
+const EncryptedPlaceholder = "<encrypted>"
+
+// DryRunRequest is a write request that --dry-run prints instead of sending
+type DryRunRequest struct {
+	Method string      `json:"method"`
+	Path   string      `json:"path"`
+	Body   interface{} `json:"body,omitempty"`
+}
+
+// AddDryRunFlag adds --dry-run to a command that changes resources
+func AddDryRunFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool("dry-run", false, "resolve and validate the input, print the requests that would be sent and exit without changing anything")
+}
+
+// DryRun reports whether --dry-run is set
+func DryRun(cmd *cobra.Command) bool {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	return dryRun
+}
+
+// PrintDryRun prints the requests a command would send, as a json list with -o json or as each
+// request line followed by its body otherwise
+func PrintDryRun(printer *utils.Printer, requests ...DryRunRequest) error {
+	if common.OutputFormat == "json" {
+		if requests == nil {
+			requests = []DryRunRequest{}
+		}
+		printer.PrintResource(utils.FormatOutput(requests, common.OutputFormat))
+		return nil
+	}
+
+	var b strings.Builder
+	for i, request := range requests {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "%s %s\n", request.Method, request.Path)
+		if request.Body == nil {
+			continue
+		}
+		body, err := json.MarshalIndent(request.Body, "", "  ")
+		if err != nil {
+			return fmt.Errorf("unable to render request body: %w", err)
+		}
+		b.Write(body)
+		b.WriteString("\n")
+	}
+	if len(requests) == 0 {
+		b.WriteString("no requests would be sent\n")
+	}
+	printer.PrintResource(strings.TrimSuffix(b.String(), "\n"))
+	printer.PrintWarning("dry run, nothing was changed")
+	return nil
+}
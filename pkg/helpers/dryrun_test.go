@@ -0,0 +1,45 @@
+package helpers
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/qernal/cli-qernal/pkg/common"
+	"github.com/qernal/cli-qernal/pkg/utils"
+	openapi_chaos_client "github.com/qernal/openapi-chaos-go-client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintDryRun(t *testing.T) {
+	printer := utils.NewPrinter()
+	var out, errOut bytes.Buffer
+	printer.SetOut(&out)
+	printer.SetErr(&errOut)
+
+	requests := []DryRunRequest{
+		CreateFunctionRequest(openapi_chaos_client.FunctionBody{Name: "api"}),
+		DeleteFunctionRequest("2d8d8b4a-3b1b-4e45-9c55-8e2a6a1e3c6d"),
+	}
+	require.NoError(t, PrintDryRun(printer, requests...))
+	assert.Contains(t, out.String(), "POST /functions\n{\n")
+	assert.Contains(t, out.String(), `"name": "api"`)
+	assert.Contains(t, out.String(), "\n\nDELETE /functions/2d8d8b4a-3b1b-4e45-9c55-8e2a6a1e3c6d")
+	assert.Contains(t, errOut.String(), "dry run, nothing was changed")
+
+	defer func(format string) { common.OutputFormat = format }(common.OutputFormat)
+	common.OutputFormat = "json"
+	out.Reset()
+	require.NoError(t, PrintDryRun(printer, requests...))
+
+	var printed []map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &printed))
+	require.Len(t, printed, 2)
+	assert.Equal(t, "DELETE", printed[1]["method"])
+	assert.NotContains(t, printed[1], "body")
+
+	out.Reset()
+	require.NoError(t, PrintDryRun(printer))
+	assert.Equal(t, "[]\n", out.String())
+}
@@ -3,54 +3,26 @@ package helpers
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
-	"io/ioutil"
 	"log/slog"
+	"net/http"
+	"os"
 	"strings"
 
 	"github.com/qernal/cli-qernal/pkg/client"
 	"github.com/qernal/cli-qernal/pkg/utils"
 	openapi_chaos_client "github.com/qernal/openapi-chaos-go-client"
+	"github.com/spf13/cobra"
 	"sigs.k8s.io/yaml"
 )
 
 // ParseFunctionConfig reads and parses the function configurations from a file
 func ParseFunctionConfig(file string, printer *utils.Printer) ([]openapi_chaos_client.FunctionBody, error) {
-	// Read the file content
-	content, err := ioutil.ReadFile(file)
+	documents, err := LoadFunctionDocuments([]string{file}, os.Stdin, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error reading function file: %w", err)
+		return nil, err
 	}
-
-	// Split the content by YAML document separator
-	documents := strings.Split(string(content), "---")
-	var functions []openapi_chaos_client.FunctionBody
-
-	for _, doc := range documents {
-		if strings.TrimSpace(doc) == "" {
-			continue
-		}
-
-		var rawData interface{}
-		if err := yaml.Unmarshal([]byte(doc), &rawData); err != nil {
-			return nil, fmt.Errorf("error parsing YAML: %w", err)
-		}
-
-		jsonData, err := json.Marshal(rawData)
-		if err != nil {
-			return nil, fmt.Errorf("error converting to JSON: %w", err)
-		}
-
-		var config openapi_chaos_client.FunctionBody
-		if err := json.Unmarshal(jsonData, &config); err != nil {
-			return nil, fmt.Errorf("error parsing JSON to config: %w", err)
-		}
-
-		functions = append(functions, config)
-	}
-
-	return functions, nil
+	return FunctionBodies(documents), nil
 }
 
 func PaginateFunctions(printer *utils.Printer, ctx context.Context, qc *client.QernalAPIClient, maxResults int32, projectId string) ([]openapi_chaos_client.Function, error) {
@@ -61,7 +33,7 @@ func PaginateFunctions(printer *utils.Printer, ctx context.Context, qc *client.Q
 		if data, ok := resData.(map[string]interface{}); ok {
 			if innerData, ok := data["data"].(map[string]interface{}); ok {
 				if nameErr, ok := innerData["name"].(string); ok {
-					return nil, printer.RenderError("unable to list functions", errors.New(nameErr))
+					return nil, printer.RenderError("unable to list functions", client.NewResponseError(nameErr, err))
 				}
 			}
 		}
@@ -95,7 +67,7 @@ func PaginateFunctions(printer *utils.Printer, ctx context.Context, qc *client.Q
 			if data, ok := resData.(map[string]interface{}); ok {
 				if innerData, ok := data["data"].(map[string]interface{}); ok {
 					if nameErr, ok := innerData["name"].(string); ok {
-						return nil, printer.RenderError("unable to list functions", errors.New(nameErr))
+						return nil, printer.RenderError("unable to list functions", client.NewResponseError(nameErr, err))
 					}
 				}
 			}
@@ -115,6 +87,77 @@ func PaginateFunctions(printer *utils.Printer, ctx context.Context, qc *client.Q
 	return allFunctions, nil
 }
 
+// ResolveFunctionID returns the id of a function given either its id or its name, names are looked up
+// within the project given with --project-id or --project
+func ResolveFunctionID(cmd *cobra.Command, qc *client.QernalAPIClient, printer *utils.Printer, function string) (string, error) {
+	if uuidPattern.MatchString(function) {
+		return function, nil
+	}
+
+	projectID, _ := cmd.Flags().GetString("project-id")
+	project, _ := cmd.Flags().GetString("project")
+	if projectID == "" && project == "" {
+		return "", utils.UsageError(fmt.Sprintf("--project-id or --project is required to find function %s by name", function))
+	}
+
+	projectID, err := GetProjectID(cmd, qc)
+	if err != nil {
+		return "", err
+	}
+	return ResolveProjectFunctionID(context.Background(), qc, printer, projectID, function)
+}
+
+// ResolveProjectFunctionID returns the id of a function given either its id or its name within projectID
+func ResolveProjectFunctionID(ctx context.Context, qc *client.QernalAPIClient, printer *utils.Printer, projectID string, function string) (string, error) {
+	if uuidPattern.MatchString(function) {
+		return function, nil
+	}
+
+	functions, err := PaginateFunctions(printer, ctx, qc, 0, projectID)
+	if err != nil {
+		return "", err
+	}
+
+	match, err := FindFunctionByName(functions, function)
+	if err != nil {
+		return "", err
+	}
+	return match.Id, nil
+}
+
+// FindFunctionByName returns the only function called name, it fails if there are none or several
+func FindFunctionByName(functions []openapi_chaos_client.Function, name string) (openapi_chaos_client.Function, error) {
+	var matches []openapi_chaos_client.Function
+	for _, function := range functions {
+		if function.Name == name {
+			matches = append(matches, function)
+		}
+	}
+
+	switch len(matches) {
+	case 1:
+		return matches[0], nil
+	case 0:
+		message := fmt.Sprintf("unable to find function with name %s", name)
+		best, bestDistance := "", 3
+		for _, function := range functions {
+			if d := editDistance(strings.ToLower(name), strings.ToLower(function.Name)); d < bestDistance {
+				best, bestDistance = function.Name, d
+			}
+		}
+		if best != "" {
+			message += fmt.Sprintf(", did you mean %s?", best)
+		}
+		return openapi_chaos_client.Function{}, utils.NewError(utils.ExitNotFound, message)
+	default:
+		ids := make([]string, 0, len(matches))
+		for _, function := range matches {
+			ids = append(ids, function.Id)
+		}
+		return openapi_chaos_client.Function{}, utils.UsageError(fmt.Sprintf("function name %s is ambiguous, it matches %s, use the function id instead", name, strings.Join(ids, ", ")))
+	}
+}
+
 func DeploymentsToOepnAPI(deployments []openapi_chaos_client.FunctionDeployment) []openapi_chaos_client.FunctionDeploymentBody {
 	var openAPIDeploymentBody []openapi_chaos_client.FunctionDeploymentBody
 
@@ -183,3 +226,224 @@ func OpenAPIDeploymentsToDeployments(openAPIDeployments []openapi_chaos_client.F
 
 	return deployments
 }
+
+// FunctionToBody converts a live function back to the definition it was created from, server managed
+// fields such as the id and revision are dropped
+func FunctionToBody(function openapi_chaos_client.Function) openapi_chaos_client.FunctionBody {
+	return NormaliseFunctionBody(openapi_chaos_client.FunctionBody{
+		ProjectId:   function.ProjectId,
+		Version:     function.Version,
+		Name:        function.Name,
+		Description: function.Description,
+		Image:       function.Image,
+		Type:        function.Type,
+		Size:        function.Size,
+		Port:        function.Port,
+		Routes:      function.Routes,
+		Scaling:     function.Scaling,
+		Deployments: DeploymentsToOepnAPI(function.Deployments),
+		Secrets:     function.Secrets,
+		Compliance:  function.Compliance,
+	})
+}
+
+// BodyToFunction builds the payload to update function id at the given revision with a definition
+func BodyToFunction(body openapi_chaos_client.FunctionBody, id string, revision string) openapi_chaos_client.Function {
+	return openapi_chaos_client.Function{
+		Id:          id,
+		ProjectId:   body.ProjectId,
+		Version:     body.Version,
+		Name:        body.Name,
+		Description: body.Description,
+		Image:       body.Image,
+		Revision:    revision,
+		Type:        body.Type,
+		Size:        body.Size,
+		Port:        body.Port,
+		Routes:      body.Routes,
+		Scaling:     body.Scaling,
+		Deployments: OpenAPIDeploymentsToDeployments(body.Deployments),
+		Secrets:     body.Secrets,
+		Compliance:  body.Compliance,
+	}
+}
+
+// NormaliseFunctionBody replaces empty lists with their canonical form so equivalent definitions compare equal
+func NormaliseFunctionBody(body openapi_chaos_client.FunctionBody) openapi_chaos_client.FunctionBody {
+	if len(body.Routes) == 0 {
+		body.Routes = nil
+	}
+	if body.Deployments == nil {
+		body.Deployments = []openapi_chaos_client.FunctionDeploymentBody{}
+	}
+	if body.Secrets == nil {
+		body.Secrets = []openapi_chaos_client.FunctionEnv{}
+	}
+	if body.Compliance == nil {
+		body.Compliance = []openapi_chaos_client.FunctionCompliance{}
+	}
+	return body
+}
+
+// FunctionChanged reports whether applying body would change the live function
+func FunctionChanged(live openapi_chaos_client.Function, body openapi_chaos_client.FunctionBody) bool {
+	current, err := json.Marshal(FunctionToBody(live))
+	if err != nil {
+		return true
+	}
+	desired, err := json.Marshal(NormaliseFunctionBody(body))
+	if err != nil {
+		return true
+	}
+	return string(current) != string(desired)
+}
+
+// CreateFunction creates a function from its definition, the applied spec is kept in the local history
+func CreateFunction(ctx context.Context, qc *client.QernalAPIClient, printer *utils.Printer, body openapi_chaos_client.FunctionBody) (*openapi_chaos_client.Function, error) {
+	qFunc, httpRes, err := qc.FunctionsAPI.FunctionsCreate(ctx).FunctionBody(body).Execute()
+	if err != nil {
+		return nil, FunctionRequestError(printer, fmt.Sprintf("unable to create function with name %s", body.Name), httpRes, err)
+	}
+	recordSnapshot(printer, qFunc)
+	return qFunc, nil
+}
+
+// UpdateFunction replaces function id with a definition, revision must be the function's current revision.
+// The applied spec is kept in the local history.
+func UpdateFunction(ctx context.Context, qc *client.QernalAPIClient, printer *utils.Printer, id string, revision string, body openapi_chaos_client.FunctionBody) (*openapi_chaos_client.Function, error) {
+	qFunc, httpRes, err := qc.FunctionsAPI.FunctionsUpdate(ctx, id).Function(BodyToFunction(body, id, revision)).Execute()
+	if err != nil {
+		return nil, FunctionRequestError(printer, fmt.Sprintf("unable to update function with name %s", body.Name), httpRes, err)
+	}
+	recordSnapshot(printer, qFunc)
+	return qFunc, nil
+}
+
+// CreateFunctionRequest is the request CreateFunction sends for body, for --dry-run
+func CreateFunctionRequest(body openapi_chaos_client.FunctionBody) DryRunRequest {
+	return DryRunRequest{Method: http.MethodPost, Path: "/functions", Body: body}
+}
+
+// UpdateFunctionRequest is the request UpdateFunction sends for body, for --dry-run
+func UpdateFunctionRequest(id string, revision string, body openapi_chaos_client.FunctionBody) DryRunRequest {
+	return DryRunRequest{Method: http.MethodPut, Path: "/functions/" + id, Body: BodyToFunction(body, id, revision)}
+}
+
+// DeleteFunctionRequest is the request that deletes function id, for --dry-run
+func DeleteFunctionRequest(id string) DryRunRequest {
+	return DryRunRequest{Method: http.MethodDelete, Path: "/functions/" + id}
+}
+
+// ModifyFunction reads a function, applies modify to its spec and submits it against the revision
+// that was read, so a concurrent change is reported as a conflict rather than overwritten. The
+// function is only updated if modify changed the spec, changed reports whether it was.
+func ModifyFunction(ctx context.Context, qc *client.QernalAPIClient, printer *utils.Printer, id string, modify func(body *openapi_chaos_client.FunctionBody) error) (function *openapi_chaos_client.Function, changed bool, err error) {
+	live, httpRes, err := qc.FunctionsAPI.FunctionsGet(ctx, id).Execute()
+	if err != nil {
+		return nil, false, FunctionRequestError(printer, "unable to find function", httpRes, err)
+	}
+
+	body := FunctionToBody(*live)
+	if err := modify(&body); err != nil {
+		return nil, false, err
+	}
+	if !FunctionChanged(*live, body) {
+		return live, false, nil
+	}
+
+	updated, err := UpdateFunction(ctx, qc, printer, id, live.Revision, body)
+	if err != nil {
+		if utils.ExitCode(err) == utils.ExitConflict {
+			return nil, false, utils.NewError(utils.ExitConflict, fmt.Sprintf("function %s was changed after revision %s was read, run the command again", live.Name, live.Revision), err)
+		}
+		return nil, false, err
+	}
+	return updated, true, nil
+}
+
+// ScaleDeployments sets the replicas of the deployments matching location, or of every deployment
+// if location is empty. A nil min or max is left unchanged. Locations match a provider id, continent,
+// country or city.
+func ScaleDeployments(body *openapi_chaos_client.FunctionBody, location string, min *int32, max *int32) error {
+	matched := 0
+	for i := range body.Deployments {
+		deployment := &body.Deployments[i]
+		if location != "" && !deploymentInLocation(deployment.Location, location) {
+			continue
+		}
+		matched++
+
+		if min != nil {
+			deployment.Replicas.Min = *min
+		}
+		if max != nil {
+			deployment.Replicas.Max = *max
+		}
+		if deployment.Replicas.Min < 0 || deployment.Replicas.Max < 1 {
+			return utils.UsageError("replicas must be at least 0 for --min and 1 for --max")
+		}
+		if deployment.Replicas.Min > deployment.Replicas.Max {
+			return utils.UsageError(fmt.Sprintf("replicas min %d can't be greater than max %d", deployment.Replicas.Min, deployment.Replicas.Max))
+		}
+	}
+
+	if matched == 0 {
+		if location == "" {
+			return utils.UsageError(fmt.Sprintf("function %s has no deployments", body.Name))
+		}
+		return utils.NewError(utils.ExitNotFound, fmt.Sprintf("function %s has no deployment in %s", body.Name, location))
+	}
+	return nil
+}
+
+// deploymentInLocation reports whether a deployment location matches a provider id, continent, country or city
+func deploymentInLocation(l openapi_chaos_client.Location, location string) bool {
+	if l.ProviderId == location {
+		return true
+	}
+	for _, value := range []*string{l.Continent, l.Country, l.City} {
+		if value != nil && strings.EqualFold(*value, location) {
+			return true
+		}
+	}
+	return false
+}
+
+// FunctionRequestError wraps a failed function request with the validation message returned by the API, if any
+func FunctionRequestError(printer *utils.Printer, message string, httpRes *http.Response, err error) error {
+	resData, _ := client.ParseResponseData(httpRes)
+	if data, ok := resData.(map[string]interface{}); ok {
+		if innerData, ok := data["data"].(map[string]interface{}); ok {
+			if nameErr, ok := innerData["name"].(string); ok {
+				err = client.NewResponseError(nameErr, err)
+			}
+		}
+	}
+	printer.Logger.Debug(message+", request failed",
+		slog.String("error", err.Error()),
+		slog.Any("response", resData))
+	return printer.RenderError(message, err)
+}
+
+// FunctionBodyYAML renders a function definition in the format read by ParseFunctionConfig
+func FunctionBodyYAML(body openapi_chaos_client.FunctionBody) (string, error) {
+	out, err := yaml.Marshal(NormaliseFunctionBody(body))
+	if err != nil {
+		return "", fmt.Errorf("error converting function %s to YAML: %w", body.Name, err)
+	}
+	return string(out), nil
+}
+
+// ExportFunctions renders live functions as a multi-document definition file that can be passed
+// back to functions apply, server managed fields are stripped
+func ExportFunctions(functions []openapi_chaos_client.Function) (string, error) {
+	documents := make([]string, 0, len(functions))
+	for _, function := range functions {
+		doc, err := FunctionBodyYAML(FunctionToBody(function))
+		if err != nil {
+			return "", err
+		}
+		documents = append(documents, doc)
+	}
+	return strings.Join(documents, "---\n"), nil
+}
@@ -0,0 +1,130 @@
+package helpers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/qernal/cli-qernal/pkg/utils"
+	openapi_chaos_client "github.com/qernal/openapi-chaos-go-client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testFunction() openapi_chaos_client.Function {
+	provider := "qernal"
+	return openapi_chaos_client.Function{
+		Id:        "5c7c7a39-3b1b-4e45-9c55-8e2a6a1e3c6d",
+		ProjectId: "b4b3c1d0-3b1b-4e45-9c55-8e2a6a1e3c6d",
+		Version:   "1.0.0",
+		Name:      "api",
+		Image:     "nginx:latest",
+		Revision:  "3",
+		Type:      openapi_chaos_client.FUNCTIONTYPE_HTTP,
+		Size:      openapi_chaos_client.FunctionSize{Cpu: 128, Memory: 128},
+		Port:      80,
+		Scaling:   openapi_chaos_client.FunctionScaling{Type: "cpu", Low: 10, High: 80},
+		Deployments: []openapi_chaos_client.FunctionDeployment{{
+			Location: openapi_chaos_client.Location{ProviderId: provider},
+			Replicas: openapi_chaos_client.FunctionReplicas{Min: 1, Max: 3},
+		}},
+	}
+}
+
+func TestFunctionChanged(t *testing.T) {
+	live := testFunction()
+	body := FunctionToBody(live)
+
+	assert.False(t, FunctionChanged(live, body), "a function matches its own definition")
+
+	body.Secrets = nil
+	body.Compliance = []openapi_chaos_client.FunctionCompliance{}
+	assert.False(t, FunctionChanged(live, body), "empty and missing lists are equivalent")
+
+	body.Image = "nginx:1.27"
+	assert.True(t, FunctionChanged(live, body))
+}
+
+func TestBodyToFunction(t *testing.T) {
+	live := testFunction()
+	function := BodyToFunction(FunctionToBody(live), live.Id, live.Revision)
+	assert.Equal(t, live.Revision, function.Revision)
+	assert.Equal(t, live.Deployments, function.Deployments)
+}
+
+func TestExportFunctionsRoundTrip(t *testing.T) {
+	api := testFunction()
+	worker := testFunction()
+	worker.Name = "worker"
+	worker.Type = openapi_chaos_client.FUNCTIONTYPE_WORKER
+
+	out, err := ExportFunctions([]openapi_chaos_client.Function{api, worker})
+	require.NoError(t, err)
+	assert.NotContains(t, out, api.Id)
+	assert.NotContains(t, out, "revision")
+
+	file := filepath.Join(t.TempDir(), "functions.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(out), 0600))
+
+	bodies, err := ParseFunctionConfig(file, utils.NewPrinter())
+	require.NoError(t, err)
+	require.Len(t, bodies, 2)
+	assert.False(t, FunctionChanged(api, bodies[0]))
+	assert.False(t, FunctionChanged(worker, bodies[1]))
+}
+
+func TestFindFunctionByName(t *testing.T) {
+	api := testFunction()
+	worker := testFunction()
+	worker.Id = "6d8d8b4a-4c2c-4f56-8d66-9f3b7b2f4d7e"
+	worker.Name = "worker"
+	functions := []openapi_chaos_client.Function{api, worker}
+
+	found, err := FindFunctionByName(functions, "worker")
+	require.NoError(t, err)
+	assert.Equal(t, worker.Id, found.Id)
+
+	_, err = FindFunctionByName(functions, "wroker")
+	assert.EqualError(t, err, "unable to find function with name wroker, did you mean worker?")
+	assert.Equal(t, utils.ExitNotFound, utils.ExitCode(err))
+
+	_, err = FindFunctionByName(functions, "frontend")
+	assert.EqualError(t, err, "unable to find function with name frontend")
+
+	duplicate := worker
+	duplicate.Id = "7e9e9c5b-5d3d-4a67-9e77-a04c8c3a5e8f"
+	_, err = FindFunctionByName(append(functions, duplicate), "worker")
+	assert.ErrorContains(t, err, "function name worker is ambiguous, it matches "+worker.Id+", "+duplicate.Id)
+	assert.Equal(t, utils.ExitUsage, utils.ExitCode(err))
+}
+
+func TestScaleDeployments(t *testing.T) {
+	europe, france := "Europe", "France"
+	function := testFunction()
+	function.Deployments = append(function.Deployments, openapi_chaos_client.FunctionDeployment{
+		Location: openapi_chaos_client.Location{ProviderId: "qernal", Continent: &europe, Country: &france},
+		Replicas: openapi_chaos_client.FunctionReplicas{Min: 1, Max: 3},
+	})
+	two, ten := int32(2), int32(10)
+
+	body := FunctionToBody(function)
+	require.NoError(t, ScaleDeployments(&body, "", &two, &ten))
+	for _, deployment := range body.Deployments {
+		assert.Equal(t, openapi_chaos_client.FunctionReplicas{Min: 2, Max: 10}, deployment.Replicas)
+	}
+
+	body = FunctionToBody(function)
+	require.NoError(t, ScaleDeployments(&body, "france", nil, &ten))
+	assert.Equal(t, int32(3), body.Deployments[0].Replicas.Max, "other locations are left alone")
+	assert.Equal(t, openapi_chaos_client.FunctionReplicas{Min: 1, Max: 10}, body.Deployments[1].Replicas, "an unset min is kept")
+
+	body = FunctionToBody(function)
+	err := ScaleDeployments(&body, "Asia", &two, nil)
+	assert.EqualError(t, err, "function api has no deployment in Asia")
+	assert.Equal(t, utils.ExitNotFound, utils.ExitCode(err))
+
+	body = FunctionToBody(function)
+	err = ScaleDeployments(&body, "", &ten, nil)
+	assert.EqualError(t, err, "replicas min 10 can't be greater than max 3")
+	assert.Equal(t, utils.ExitUsage, utils.ExitCode(err))
+}
@@ -0,0 +1,249 @@
+package helpers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/qernal/cli-qernal/pkg/client"
+	"github.com/qernal/cli-qernal/pkg/utils"
+	openapi_chaos_client "github.com/qernal/openapi-chaos-go-client"
+)
+
+// maxSnapshots is the number of revisions kept locally for every function
+const maxSnapshots = 50
+
+// historyDir holds a snapshot file for every function applied from this machine
+var historyDir = filepath.Join(os.Getenv("HOME"), ".qernal", "history")
+
+// FunctionSnapshot is a revision of a function applied by the CLI
+type FunctionSnapshot struct {
+	Revision  string                            `json:"revision"`
+	AppliedAt time.Time                         `json:"applied_at"`
+	Spec      openapi_chaos_client.FunctionBody `json:"spec"`
+}
+
+// RevisionChange summarises how a revision differs from the one before it
+type RevisionChange struct {
+	Fields  []string `json:"fields"`
+	Added   int      `json:"added"`
+	Removed int      `json:"removed"`
+}
+
+func (c RevisionChange) String() string {
+	if len(c.Fields) == 0 {
+		return "no changes"
+	}
+	return fmt.Sprintf("%s (+%d -%d)", strings.Join(c.Fields, ", "), c.Added, c.Removed)
+}
+
+// FunctionRevision is a revision of a function with when it was applied, if known
+type FunctionRevision struct {
+	Revision  string                            `json:"revision"`
+	AppliedAt *time.Time                        `json:"applied_at,omitempty"`
+	Current   bool                              `json:"current"`
+	Changes   *RevisionChange                   `json:"changes,omitempty"`
+	Spec      openapi_chaos_client.FunctionBody `json:"spec"`
+}
+
+func snapshotFile(functionID string) string {
+	return filepath.Join(historyDir, functionID+".json")
+}
+
+// ReadSnapshots returns the revisions of a function applied from this machine, oldest first
+func ReadSnapshots(functionID string) ([]FunctionSnapshot, error) {
+	content, err := os.ReadFile(snapshotFile(functionID))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading function history: %w", err)
+	}
+
+	var snapshots []FunctionSnapshot
+	if err := json.Unmarshal(content, &snapshots); err != nil {
+		return nil, fmt.Errorf("error parsing function history %s: %w", snapshotFile(functionID), err)
+	}
+	return snapshots, nil
+}
+
+// RecordSnapshot stores the spec of an applied function, only the latest maxSnapshots are kept
+func RecordSnapshot(function openapi_chaos_client.Function) error {
+	snapshots, err := ReadSnapshots(function.Id)
+	if err != nil {
+		return err
+	}
+
+	snapshots = append(snapshots, FunctionSnapshot{
+		Revision:  function.Revision,
+		AppliedAt: time.Now().UTC(),
+		Spec:      FunctionToBody(function),
+	})
+	if len(snapshots) > maxSnapshots {
+		snapshots = snapshots[len(snapshots)-maxSnapshots:]
+	}
+
+	content, err := json.MarshalIndent(snapshots, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error writing function history: %w", err)
+	}
+	if err := os.MkdirAll(historyDir, 0700); err != nil {
+		return fmt.Errorf("error creating history directory: %w", err)
+	}
+	return os.WriteFile(snapshotFile(function.Id), content, 0600)
+}
+
+// recordSnapshot records an applied function, history is best effort so failures are only logged
+func recordSnapshot(printer *utils.Printer, function *openapi_chaos_client.Function) {
+	if err := RecordSnapshot(*function); err != nil {
+		printer.Logger.Debug("unable to record function history", slog.String("error", err.Error()))
+	}
+}
+
+// ListFunctionRevisions returns every revision of a function known to the API
+func ListFunctionRevisions(ctx context.Context, qc *client.QernalAPIClient, functionID string) ([]openapi_chaos_client.Function, error) {
+	pageSize := int32(20)
+	var revisions []openapi_chaos_client.Function
+	for page := int32(1); ; page++ {
+		previous := page - 1
+		resp, httpRes, err := qc.FunctionsAPI.FunctionsRevisionsList(ctx, functionID).
+			Page(openapi_chaos_client.OrganisationsListPageParameter{
+				Size:   &pageSize,
+				Before: &previous,
+				After:  &page,
+			}).Execute()
+		if err != nil {
+			resData, _ := client.ParseResponseData(httpRes)
+			return nil, fmt.Errorf("unable to list revisions: %w, detail: %v", err, resData)
+		}
+		revisions = append(revisions, resp.GetData()...)
+		if page >= resp.Meta.Pages {
+			return revisions, nil
+		}
+	}
+}
+
+// FunctionHistory combines the revisions from the API with the local snapshots of a function, oldest
+// first. Revisions only known locally are included, so history is available even if the API has
+// forgotten a revision.
+func FunctionHistory(live openapi_chaos_client.Function, revisions []openapi_chaos_client.Function, snapshots []FunctionSnapshot) []FunctionRevision {
+	byRevision := map[string]*FunctionRevision{}
+	var history []*FunctionRevision
+
+	add := func(revision string, spec openapi_chaos_client.FunctionBody) *FunctionRevision {
+		if entry, ok := byRevision[revision]; ok {
+			return entry
+		}
+		entry := &FunctionRevision{Revision: revision, Spec: spec}
+		byRevision[revision] = entry
+		history = append(history, entry)
+		return entry
+	}
+
+	for _, revision := range revisions {
+		add(revision.Revision, FunctionToBody(revision))
+	}
+	for _, snapshot := range snapshots {
+		appliedAt := snapshot.AppliedAt
+		add(snapshot.Revision, snapshot.Spec).AppliedAt = &appliedAt
+	}
+	add(live.Revision, FunctionToBody(live)).Current = true
+
+	sort.SliceStable(history, func(i, j int) bool {
+		return revisionLess(history[i].Revision, history[j].Revision)
+	})
+
+	result := make([]FunctionRevision, 0, len(history))
+	for i, entry := range history {
+		if i > 0 {
+			change := SummariseChange(history[i-1].Spec, entry.Spec)
+			entry.Changes = &change
+		}
+		result = append(result, *entry)
+	}
+	return result
+}
+
+// revisionLess orders numeric revisions numerically and anything else as strings
+func revisionLess(a string, b string) bool {
+	na, errA := strconv.Atoi(a)
+	nb, errB := strconv.Atoi(b)
+	if errA == nil && errB == nil {
+		return na < nb
+	}
+	return a < b
+}
+
+// SummariseChange lists the top-level fields that differ between two specs and the number of
+// definition lines added and removed
+func SummariseChange(from openapi_chaos_client.FunctionBody, to openapi_chaos_client.FunctionBody) RevisionChange {
+	change := RevisionChange{Fields: []string{}}
+
+	fromFields, toFields := specFields(from), specFields(to)
+	for field := range fromFields {
+		if _, ok := toFields[field]; !ok {
+			change.Fields = append(change.Fields, field)
+		}
+	}
+	for field, value := range toFields {
+		if string(fromFields[field]) != string(value) {
+			change.Fields = append(change.Fields, field)
+		}
+	}
+	sort.Strings(change.Fields)
+
+	fromYAML, _ := FunctionBodyYAML(from)
+	toYAML, _ := FunctionBodyYAML(to)
+	for _, op := range diffLines(splitLines(fromYAML), splitLines(toYAML)) {
+		switch op.kind {
+		case '+':
+			change.Added++
+		case '-':
+			change.Removed++
+		}
+	}
+	return change
+}
+
+// specFields returns the json encoding of every top-level field of a spec
+func specFields(spec openapi_chaos_client.FunctionBody) map[string]json.RawMessage {
+	fields := map[string]json.RawMessage{}
+	content, err := json.Marshal(NormaliseFunctionBody(spec))
+	if err != nil {
+		return fields
+	}
+	_ = json.Unmarshal(content, &fields)
+	return fields
+}
+
+// FunctionRevisionSpec returns the spec of a revision from the API, or from the local snapshots if
+// the API doesn't have it
+func FunctionRevisionSpec(ctx context.Context, qc *client.QernalAPIClient, printer *utils.Printer, functionID string, revision string) (openapi_chaos_client.FunctionBody, error) {
+	qFunc, httpRes, err := qc.FunctionsAPI.FunctionsRevisionsGet(ctx, functionID, revision).Execute()
+	if err == nil {
+		return FunctionToBody(*qFunc), nil
+	}
+	resData, _ := client.ParseResponseData(httpRes)
+	printer.Logger.Debug("unable to get revision, falling back to local history",
+		slog.String("error", err.Error()),
+		slog.Any("response", resData))
+
+	snapshots, snapshotErr := ReadSnapshots(functionID)
+	if snapshotErr != nil {
+		return openapi_chaos_client.FunctionBody{}, snapshotErr
+	}
+	for i := len(snapshots) - 1; i >= 0; i-- {
+		if snapshots[i].Revision == revision {
+			return snapshots[i].Spec, nil
+		}
+	}
+	return openapi_chaos_client.FunctionBody{}, utils.NewError(utils.ExitNotFound, fmt.Sprintf("unable to find revision %s of function", revision), err)
+}
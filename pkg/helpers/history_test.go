@@ -0,0 +1,71 @@
+package helpers
+
+import (
+	"testing"
+	"time"
+
+	openapi_chaos_client "github.com/qernal/openapi-chaos-go-client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordSnapshot(t *testing.T) {
+	defaultDir := historyDir
+	historyDir = t.TempDir()
+	t.Cleanup(func() { historyDir = defaultDir })
+
+	function := testFunction()
+	snapshots, err := ReadSnapshots(function.Id)
+	require.NoError(t, err)
+	assert.Empty(t, snapshots)
+
+	for i := 0; i < maxSnapshots+5; i++ {
+		require.NoError(t, RecordSnapshot(function))
+	}
+	function.Revision = "4"
+	function.Image = "nginx:1.27"
+	require.NoError(t, RecordSnapshot(function))
+
+	snapshots, err = ReadSnapshots(function.Id)
+	require.NoError(t, err)
+	require.Len(t, snapshots, maxSnapshots, "only the latest snapshots are kept")
+	last := snapshots[len(snapshots)-1]
+	assert.Equal(t, "4", last.Revision)
+	assert.Equal(t, "nginx:1.27", last.Spec.Image)
+	assert.WithinDuration(t, time.Now(), last.AppliedAt, time.Minute)
+}
+
+func TestFunctionHistory(t *testing.T) {
+	first := testFunction()
+	first.Revision = "1"
+	second := testFunction()
+	second.Revision = "2"
+	second.Image = "nginx:1.27"
+	second.Deployments[0].Replicas.Max = 5
+	live := testFunction()
+	live.Revision = "10"
+
+	appliedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	snapshots := []FunctionSnapshot{
+		{Revision: "2", AppliedAt: appliedAt, Spec: FunctionToBody(second)},
+		{Revision: "10", AppliedAt: appliedAt.Add(time.Hour), Spec: FunctionToBody(live)},
+	}
+
+	history := FunctionHistory(live, []openapi_chaos_client.Function{second, first}, snapshots)
+	require.Len(t, history, 3)
+
+	assert.Equal(t, []string{"1", "2", "10"}, []string{history[0].Revision, history[1].Revision, history[2].Revision}, "revisions are ordered numerically")
+	assert.Nil(t, history[0].Changes)
+	assert.Nil(t, history[0].AppliedAt, "revisions only known to the API have no applied time")
+	assert.Equal(t, appliedAt, *history[1].AppliedAt)
+	assert.Equal(t, []string{"deployments", "image"}, history[1].Changes.Fields)
+	assert.Equal(t, "deployments, image (+2 -2)", history[1].Changes.String())
+	assert.True(t, history[2].Current)
+	assert.False(t, history[1].Current)
+	assert.Equal(t, "deployments, image (+2 -2)", history[2].Changes.String())
+}
+
+func TestSummariseChangeUnchanged(t *testing.T) {
+	body := FunctionToBody(testFunction())
+	assert.Equal(t, "no changes", SummariseChange(body, body).String())
+}
@@ -39,6 +39,23 @@ func GetDefaultHost(projid string) (string, error) {
 	return "", errors.New("no default host on project")
 }
 
+// FunctionHost returns the host to reach the functions of projectID on, the project's default
+// *.qrnl.app host unless name is set
+func FunctionHost(ctx context.Context, qc *client.QernalAPIClient, projectID string, name string) (string, error) {
+	if name == "" {
+		return GetDefaultHost(projectID)
+	}
+
+	host, _, err := qc.HostsAPI.ProjectsHostsGet(ctx, projectID, name).Execute()
+	if err != nil {
+		return "", fmt.Errorf("unable to find host %s: %w", name, err)
+	}
+	if host.Disabled {
+		return "", fmt.Errorf("host %s is disabled", name)
+	}
+	return host.Host, nil
+}
+
 func GetHostState(disabled bool) string {
 	if disabled {
 		return "Disabled"
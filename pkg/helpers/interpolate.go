@@ -0,0 +1,139 @@
+package helpers
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Variables are the values of ${VAR} references in function definitions, variables that aren't set
+// fall back to the environment
+type Variables map[string]string
+
+// Lookup returns the value of a variable, from v first and the environment second
+func (v Variables) Lookup(name string) (string, bool) {
+	if value, ok := v[name]; ok {
+		return value, true
+	}
+	return os.LookupEnv(name)
+}
+
+// ParseVariables parses key=value pairs, later pairs override earlier ones
+func ParseVariables(pairs []string) (Variables, error) {
+	vars := Variables{}
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || !variableNamePattern.MatchString(key) {
+			return nil, fmt.Errorf("invalid variable %q, expected key=value", pair)
+		}
+		vars[key] = value
+	}
+	return vars, nil
+}
+
+// VariableError is a reference to a variable that isn't set and has no default, positions are 1-based
+type VariableError struct {
+	Name   string
+	Line   int
+	Column int
+}
+
+func (e VariableError) Error() string {
+	return fmt.Sprintf("%d:%d: variable %s is not set, set it in the environment or with --var %s=<value>", e.Line, e.Column, e.Name, e.Name)
+}
+
+var (
+	variableNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	// matches $${ (an escaped ${), ${VAR} and ${VAR:-default}
+	variablePattern = regexp.MustCompile(`\$\$\{|\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+)
+
+// Interpolate replaces ${VAR} and ${VAR:-default} in the scalar values of a parsed yaml document with
+// the value of VAR, the default is used when VAR is unset or empty. $${ is kept as a literal ${. Keys
+// and comments are left alone, and the nodes keep their positions, so a value can contain yaml syntax
+// without changing the structure of the document. Every reference to a variable without a value is
+// returned as an error.
+func Interpolate(doc *yaml.Node, vars Variables) []VariableError {
+	var problems []VariableError
+	switch doc.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, n := range doc.Content {
+			problems = append(problems, Interpolate(n, vars)...)
+		}
+	case yaml.MappingNode:
+		for i := 1; i < len(doc.Content); i += 2 {
+			problems = append(problems, Interpolate(doc.Content[i], vars)...)
+		}
+	case yaml.ScalarNode:
+		problems = interpolateScalar(doc, vars)
+	}
+	return problems
+}
+
+// interpolateScalar interpolates the value of a scalar node. A plain value is resolved again, so
+// port: ${PORT} is an integer once it is interpolated.
+func interpolateScalar(n *yaml.Node, vars Variables) []VariableError {
+	matches := variablePattern.FindAllStringSubmatchIndex(n.Value, -1)
+	if len(matches) == 0 {
+		return nil
+	}
+
+	var problems []VariableError
+	var out strings.Builder
+	last := 0
+	for _, m := range matches {
+		out.WriteString(n.Value[last:m[0]])
+		last = m[1]
+
+		if m[2] < 0 {
+			out.WriteString("${")
+			continue
+		}
+
+		name := n.Value[m[2]:m[3]]
+		value, ok := vars.Lookup(name)
+		if m[4] >= 0 && value == "" {
+			value, ok = n.Value[m[6]:m[7]], true
+		}
+		if !ok {
+			line, column := valuePosition(n, m[0])
+			problems = append(problems, VariableError{Name: name, Line: line, Column: column})
+			continue
+		}
+		out.WriteString(value)
+	}
+	out.WriteString(n.Value[last:])
+
+	n.Value = out.String()
+	if n.Style == 0 {
+		n.Tag = ""
+	}
+	return problems
+}
+
+// valuePosition returns the 1-based line and column of a byte offset in the value of a scalar node.
+// Offsets in single line values are exact, in other values the position of the value is returned.
+func valuePosition(n *yaml.Node, offset int) (int, int) {
+	switch {
+	case strings.Contains(n.Value, "\n"):
+		return n.Line, n.Column
+	case n.Style == 0:
+		return n.Line, n.Column + offset
+	case n.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) != 0 && n.Style&yaml.TaggedStyle == 0:
+		return n.Line, n.Column + 1 + offset
+	default:
+		return n.Line, n.Column
+	}
+}
+
+// variableErrors joins the errors of a source into a single error
+func variableErrors(source string, problems []VariableError) error {
+	messages := make([]string, 0, len(problems))
+	for _, problem := range problems {
+		messages = append(messages, source+":"+problem.Error())
+	}
+	return fmt.Errorf("%s", strings.Join(messages, "\n"))
+}
@@ -0,0 +1,85 @@
+package helpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// interpolateYAML parses content, interpolates it with vars and decodes the result
+func interpolateYAML(t *testing.T, content string, vars Variables) (interface{}, []VariableError) {
+	t.Helper()
+	var doc yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(content), &doc))
+	problems := Interpolate(&doc, vars)
+
+	var out interface{}
+	require.NoError(t, doc.Decode(&out))
+	return out, problems
+}
+
+func TestInterpolate(t *testing.T) {
+	t.Setenv("QERNAL_TEST_IMAGE", "nginx:1.27")
+	t.Setenv("QERNAL_TEST_EMPTY", "")
+
+	testCases := []struct {
+		name     string
+		content  string
+		vars     Variables
+		expected interface{}
+	}{
+		{name: "environment", content: "image: ${QERNAL_TEST_IMAGE}", expected: "nginx:1.27"},
+		{name: "var overrides environment", content: "image: ${QERNAL_TEST_IMAGE}", vars: Variables{"QERNAL_TEST_IMAGE": "nginx:1.28"}, expected: "nginx:1.28"},
+		{name: "default", content: "port: ${QERNAL_TEST_PORT:-8080}", expected: 8080},
+		{name: "default for empty value", content: "port: ${QERNAL_TEST_EMPTY:-8080}", expected: 8080},
+		{name: "empty default", content: "description: \"${QERNAL_TEST_UNSET:-}\"", expected: ""},
+		{name: "quoted values stay strings", content: "version: \"${QERNAL_TEST_VERSION:-1}\"", expected: "1"},
+		{name: "escaped", content: "description: $${QERNAL_TEST_IMAGE} costs $5", expected: "${QERNAL_TEST_IMAGE} costs $5"},
+		{name: "yaml syntax in values", content: "description: ${DESCRIPTION}", vars: Variables{"DESCRIPTION": "a: b\n- c # d"}, expected: "a: b\n- c # d"},
+		{name: "flow syntax in values", content: "description: ${DESCRIPTION}", vars: Variables{"DESCRIPTION": "[a, {b: c}]"}, expected: "[a, {b: c}]"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			out, problems := interpolateYAML(t, tc.content, tc.vars)
+			assert.Empty(t, problems)
+			require.IsType(t, map[string]interface{}{}, out)
+			for _, value := range out.(map[string]interface{}) {
+				assert.Equal(t, tc.expected, value)
+			}
+		})
+	}
+}
+
+func TestInterpolateComments(t *testing.T) {
+	content := `# image: ${QERNAL_TEST_UNSET}
+name: api # defaults to ${QERNAL_TEST_UNSET}
+${QERNAL_TEST_KEY}: value
+`
+	out, problems := interpolateYAML(t, content, nil)
+	assert.Empty(t, problems, "comments and keys aren't interpolated")
+	assert.Equal(t, map[string]interface{}{"name": "api", "${QERNAL_TEST_KEY}": "value"}, out)
+}
+
+func TestInterpolateUndefined(t *testing.T) {
+	content := "name: api\nimage: ${QERNAL_TEST_UNSET}\ntags:\n  - v1-${QERNAL_TEST_TAG}\n  - \"${QERNAL_TEST_TAG}\"\n"
+	_, problems := interpolateYAML(t, content, nil)
+	assert.Equal(t, []VariableError{
+		{Name: "QERNAL_TEST_UNSET", Line: 2, Column: 8},
+		{Name: "QERNAL_TEST_TAG", Line: 4, Column: 8},
+		{Name: "QERNAL_TEST_TAG", Line: 5, Column: 6},
+	}, problems)
+}
+
+func TestParseVariables(t *testing.T) {
+	vars, err := ParseVariables([]string{"IMAGE=nginx:latest", "EMPTY=", "QUERY=a=b", "IMAGE=nginx:1.27"})
+	require.NoError(t, err)
+	assert.Equal(t, Variables{"IMAGE": "nginx:1.27", "EMPTY": "", "QUERY": "a=b"}, vars)
+
+	for _, invalid := range []string{"IMAGE", "=value", "1IMAGE=x", "MY-IMAGE=x"} {
+		_, err := ParseVariables([]string{invalid})
+		assert.Error(t, err, invalid)
+	}
+}
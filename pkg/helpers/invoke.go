@@ -0,0 +1,162 @@
+package helpers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	openapi_chaos_client "github.com/qernal/openapi-chaos-go-client"
+)
+
+// InvokeRequest is an HTTP request sent to a function
+type InvokeRequest struct {
+	Method string
+	URL    string
+	Header http.Header
+	Body   []byte
+}
+
+// NewRequest builds a request that can be sent once, call it again for every attempt
+func (r InvokeRequest) NewRequest(ctx context.Context) (*http.Request, error) {
+	var body io.Reader
+	if r.Body != nil {
+		body = bytes.NewReader(r.Body)
+	}
+	req, err := http.NewRequestWithContext(ctx, r.Method, r.URL, body)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range r.Header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	// net/http takes the host from the header map only through req.Host
+	if host := r.Header.Get("Host"); host != "" {
+		req.Host = host
+	}
+	return req, nil
+}
+
+// InvokeResponse is the response of a function to an InvokeRequest
+type InvokeResponse struct {
+	Status     string      `json:"status"`
+	StatusCode int         `json:"status_code"`
+	Proto      string      `json:"proto"`
+	Header     http.Header `json:"headers"`
+	Body       string      `json:"body"`
+	Duration   string      `json:"duration"`
+}
+
+// Invoke sends request with httpClient and reads the whole response, the duration covers sending the
+// request and reading the body
+func Invoke(ctx context.Context, httpClient *http.Client, request InvokeRequest) (*InvokeResponse, error) {
+	req, err := request.NewRequest(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the response body: %w", err)
+	}
+
+	return &InvokeResponse{
+		Status:     res.Status,
+		StatusCode: res.StatusCode,
+		Proto:      res.Proto,
+		Header:     res.Header,
+		Body:       string(body),
+		Duration:   time.Since(start).Round(time.Microsecond).String(),
+	}, nil
+}
+
+// ParseHeaders parses headers given as "Name: value"
+func ParseHeaders(values []string) (http.Header, error) {
+	header := http.Header{}
+	for _, value := range values {
+		name, v, ok := strings.Cut(value, ":")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" || strings.ContainsAny(name, " \t") {
+			return nil, fmt.Errorf("header %q isn't in the form Name: value", value)
+		}
+		header.Add(name, strings.TrimSpace(v))
+	}
+	return header, nil
+}
+
+// ReadRequestData returns the body given with --data, @file reads a file and @- reads stdin
+func ReadRequestData(data string, stdin io.Reader) ([]byte, error) {
+	path, ok := strings.CutPrefix(data, "@")
+	switch {
+	case !ok:
+		return []byte(data), nil
+	case path == StdinSource:
+		return io.ReadAll(stdin)
+	default:
+		return os.ReadFile(path)
+	}
+}
+
+// FunctionURL joins a host and a path, hosts without a scheme are served over https
+func FunctionURL(host string, path string) (string, error) {
+	if !strings.Contains(host, "://") {
+		host = "https://" + host
+	}
+	base, err := url.Parse(host)
+	if err != nil {
+		return "", fmt.Errorf("invalid host %s: %w", host, err)
+	}
+	if base.Host == "" {
+		return "", fmt.Errorf("invalid host %s", host)
+	}
+
+	ref, err := url.Parse(path)
+	if err != nil {
+		return "", fmt.Errorf("invalid path %s: %w", path, err)
+	}
+	base.Path = strings.TrimSuffix(base.Path, "/") + "/" + strings.TrimPrefix(ref.Path, "/")
+	base.RawQuery = ref.RawQuery
+	return base.String(), nil
+}
+
+// MatchRoute returns the first route of a function that accepts method on path. Route paths are
+// regular expressions matched against the start of the path.
+func MatchRoute(routes []openapi_chaos_client.FunctionRoute, method string, path string) (openapi_chaos_client.FunctionRoute, bool) {
+	path, _, _ = strings.Cut(path, "?")
+	for _, route := range routes {
+		pattern, err := regexp.Compile("^(?:" + route.Path + ")")
+		if err != nil || !pattern.MatchString(path) {
+			continue
+		}
+		if slices.ContainsFunc(route.Methods, func(m string) bool { return strings.EqualFold(m, method) }) {
+			return route, true
+		}
+	}
+	return openapi_chaos_client.FunctionRoute{}, false
+}
+
+// DefaultRoutePath is the path of the first route of a function that isn't a pattern, or /
+func DefaultRoutePath(routes []openapi_chaos_client.FunctionRoute) string {
+	for _, route := range routes {
+		if regexp.QuoteMeta(route.Path) == route.Path {
+			return route.Path
+		}
+	}
+	return "/"
+}
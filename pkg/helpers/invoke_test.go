@@ -0,0 +1,116 @@
+package helpers
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	openapi_chaos_client "github.com/qernal/openapi-chaos-go-client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvoke(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("X-Method", r.Method)
+		w.Header().Set("X-Token", r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(r.URL.RequestURI() + " " + string(body)))
+	}))
+	defer server.Close()
+
+	url, err := FunctionURL(server.URL, "/orders?limit=1")
+	require.NoError(t, err)
+	header, err := ParseHeaders([]string{"Authorization: Bearer abc"})
+	require.NoError(t, err)
+
+	request := InvokeRequest{Method: http.MethodPost, URL: url, Header: header, Body: []byte(`{"id":1}`)}
+	res, err := Invoke(context.Background(), server.Client(), request)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, res.StatusCode)
+	assert.Equal(t, "201 Created", res.Status)
+	assert.Equal(t, "POST", res.Header.Get("X-Method"))
+	assert.Equal(t, "Bearer abc", res.Header.Get("X-Token"))
+	assert.Equal(t, `/orders?limit=1 {"id":1}`, res.Body)
+	assert.NotEmpty(t, res.Duration)
+
+	// the body can be sent again
+	res, err = Invoke(context.Background(), server.Client(), request)
+	require.NoError(t, err)
+	assert.Equal(t, `/orders?limit=1 {"id":1}`, res.Body)
+}
+
+func TestParseHeaders(t *testing.T) {
+	header, err := ParseHeaders([]string{"Content-Type: application/json", "X-Tag:a", "x-tag: b"})
+	require.NoError(t, err)
+	assert.Equal(t, "application/json", header.Get("Content-Type"))
+	assert.Equal(t, []string{"a", "b"}, header.Values("X-Tag"))
+
+	for _, value := range []string{"Content-Type", ": value", "Content Type: json"} {
+		_, err := ParseHeaders([]string{value})
+		assert.Error(t, err, value)
+	}
+}
+
+func TestReadRequestData(t *testing.T) {
+	data, err := ReadRequestData("plain", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "plain", string(data))
+
+	data, err = ReadRequestData("@-", strings.NewReader("from stdin"))
+	require.NoError(t, err)
+	assert.Equal(t, "from stdin", string(data))
+
+	path := filepath.Join(t.TempDir(), "body.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"id":1}`), 0o600))
+	data, err = ReadRequestData("@"+path, nil)
+	require.NoError(t, err)
+	assert.Equal(t, `{"id":1}`, string(data))
+
+	_, err = ReadRequestData("@"+filepath.Join(t.TempDir(), "missing.json"), nil)
+	assert.Error(t, err)
+}
+
+func TestFunctionURL(t *testing.T) {
+	tests := []struct {
+		host, path, want string
+	}{
+		{"api-1a2b.qrnl.app", "/health", "https://api-1a2b.qrnl.app/health"},
+		{"api-1a2b.qrnl.app", "health?full=1", "https://api-1a2b.qrnl.app/health?full=1"},
+		{"http://localhost:8080/", "/", "http://localhost:8080/"},
+		{"http://localhost:8080/base", "/health", "http://localhost:8080/base/health"},
+	}
+	for _, tt := range tests {
+		got, err := FunctionURL(tt.host, tt.path)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, got)
+	}
+
+	_, err := FunctionURL("http://", "/")
+	assert.Error(t, err)
+}
+
+func TestMatchRoute(t *testing.T) {
+	routes := []openapi_chaos_client.FunctionRoute{
+		{Path: "/api/v[0-9]+", Methods: []string{"GET", "POST"}, Weight: 100},
+		{Path: "/health", Methods: []string{"GET"}, Weight: 100},
+	}
+
+	route, ok := MatchRoute(routes, "post", "/api/v2/orders?limit=1")
+	assert.True(t, ok)
+	assert.Equal(t, "/api/v[0-9]+", route.Path)
+
+	_, ok = MatchRoute(routes, "POST", "/health")
+	assert.False(t, ok, "the route doesn't accept the method")
+	_, ok = MatchRoute(routes, "GET", "/status/health")
+	assert.False(t, ok, "routes match the start of the path")
+
+	assert.Equal(t, "/health", DefaultRoutePath(routes))
+	assert.Equal(t, "/", DefaultRoutePath(nil))
+}
@@ -0,0 +1,377 @@
+package helpers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	openapi_chaos_client "github.com/qernal/openapi-chaos-go-client"
+	"gopkg.in/yaml.v3"
+)
+
+// StdinSource is the file name that reads definitions from stdin
+const StdinSource = "-"
+
+// FunctionSource is the content of a function definition file
+type FunctionSource struct {
+	Name    string
+	Content []byte
+	// Arg is the index of the path the source was matched by, sources matched by the same path
+	// can't overlay each other
+	Arg int
+}
+
+// FunctionDocument is a function definition together with where it was defined
+type FunctionDocument struct {
+	Source string
+	// Index is the 1-based position of the document in Source
+	Index int
+	// Overlays are the positions of the overlays merged into the definition
+	Overlays []string
+	// Project is the name of the project the function is deployed to, it is set instead of
+	// Body.ProjectId and resolved before the definition is submitted
+	Project string
+	Body    openapi_chaos_client.FunctionBody
+}
+
+// Position describes where the function was defined, for use in messages
+func (d FunctionDocument) Position() string {
+	position := fmt.Sprintf("%s (document %d)", d.Source, d.Index)
+	if len(d.Overlays) > 0 {
+		position += " with overlays " + strings.Join(d.Overlays, ", ")
+	}
+	return position
+}
+
+// ReadFunctionSources reads every file matched by paths. A path can be a file, a directory that is
+// searched recursively for .yaml and .yml files, a glob, or - to read from stdin.
+func ReadFunctionSources(paths []string, stdin io.Reader) ([]FunctionSource, error) {
+	if len(paths) == 0 {
+		return nil, errors.New("no function definition files given")
+	}
+
+	var sources []FunctionSource
+	seen := map[string]bool{}
+	for arg, path := range paths {
+		if path == StdinSource {
+			if seen[StdinSource] {
+				continue
+			}
+			seen[StdinSource] = true
+
+			content, err := io.ReadAll(stdin)
+			if err != nil {
+				return nil, fmt.Errorf("error reading function definitions from stdin: %w", err)
+			}
+			sources = append(sources, FunctionSource{Name: "<stdin>", Content: content, Arg: arg})
+			continue
+		}
+
+		files, err := expandFunctionPath(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			if seen[file] {
+				continue
+			}
+			seen[file] = true
+
+			content, err := os.ReadFile(file)
+			if err != nil {
+				return nil, fmt.Errorf("error reading function file: %w", err)
+			}
+			sources = append(sources, FunctionSource{Name: file, Content: content, Arg: arg})
+		}
+	}
+
+	return sources, nil
+}
+
+// expandFunctionPath returns the files matched by a path, glob or directory in a stable order
+func expandFunctionPath(path string) ([]string, error) {
+	matches := []string{path}
+	if strings.ContainsAny(path, "*?[") {
+		var err error
+		matches, err = filepath.Glob(path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %s: %w", path, err)
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no function files match %s", path)
+		}
+	}
+
+	var files []string
+	for _, match := range matches {
+		info, err := os.Stat(match)
+		if err != nil {
+			return nil, fmt.Errorf("error reading function file: %w", err)
+		}
+		if !info.IsDir() {
+			files = append(files, match)
+			continue
+		}
+
+		var dirFiles []string
+		err = filepath.WalkDir(match, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if ext := filepath.Ext(p); !d.IsDir() && (ext == ".yaml" || ext == ".yml") {
+				dirFiles = append(dirFiles, p)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error reading function directory: %w", err)
+		}
+		if len(dirFiles) == 0 {
+			return nil, fmt.Errorf("no .yaml or .yml files found in %s", match)
+		}
+		sort.Strings(dirFiles)
+		files = append(files, dirFiles...)
+	}
+
+	return files, nil
+}
+
+// LoadFunctionDocuments reads and parses every function definition matched by paths, see
+// ReadFunctionSources. ${VAR} references in values are interpolated with vars, see Interpolate. A function that
+// is defined again by a later path is an overlay, it is deep-merged into the earlier definition. A
+// function defined twice by the same path, e.g. in two files of a directory, is an error.
+func LoadFunctionDocuments(paths []string, stdin io.Reader, vars Variables) ([]FunctionDocument, error) {
+	sources, err := ReadFunctionSources(paths, stdin)
+	if err != nil {
+		return nil, err
+	}
+
+	var merged []rawDocument
+	byName := map[string]int{}
+	// definedBy is the path and position a function was last defined or overlaid by
+	definedBy := map[string]rawDocument{}
+	for _, source := range sources {
+		var problems []VariableError
+		docs, err := parseRawDocuments(source, func(doc *yaml.Node) {
+			problems = append(problems, Interpolate(doc, vars)...)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(problems) > 0 {
+			return nil, variableErrors(source.Name, problems)
+		}
+
+		for _, doc := range docs {
+			name, _ := doc.fields["name"].(string)
+			if name == "" {
+				return nil, fmt.Errorf("%s: function definition has no name", doc.position())
+			}
+
+			if previous, ok := definedBy[name]; ok && previous.arg == doc.arg {
+				return nil, fmt.Errorf("function %s is defined more than once, in %s and %s", name, previous.position(), doc.position())
+			}
+			definedBy[name] = doc
+
+			i, ok := byName[name]
+			if !ok {
+				byName[name] = len(merged)
+				merged = append(merged, doc)
+				continue
+			}
+			merged[i].fields = mergeFields(merged[i].fields, projectOverlay(merged[i].fields, doc.fields))
+			merged[i].overlayPositions = append(merged[i].overlayPositions, doc.position())
+		}
+	}
+
+	if len(merged) == 0 {
+		return nil, errors.New("no function definitions found")
+	}
+
+	documents := make([]FunctionDocument, 0, len(merged))
+	for _, doc := range merged {
+		document, err := doc.functionDocument()
+		if err != nil {
+			return nil, err
+		}
+		documents = append(documents, document)
+	}
+	return documents, nil
+}
+
+// ParseFunctionDocuments decodes every document of a yaml stream into a function definition, empty
+// documents are skipped
+func ParseFunctionDocuments(source FunctionSource) ([]FunctionDocument, error) {
+	docs, err := parseRawDocuments(source, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	documents := make([]FunctionDocument, 0, len(docs))
+	for _, doc := range docs {
+		document, err := doc.functionDocument()
+		if err != nil {
+			return nil, err
+		}
+		documents = append(documents, document)
+	}
+	return documents, nil
+}
+
+// rawDocument is a function definition before it is merged with its overlays and converted
+type rawDocument struct {
+	source string
+	// arg is the index of the path the source was matched by
+	arg    int
+	index  int
+	fields map[string]interface{}
+	// overlayPositions are the positions of the overlays merged into the definition
+	overlayPositions []string
+}
+
+func (d rawDocument) position() string {
+	return fmt.Sprintf("%s (document %d)", d.source, d.index)
+}
+
+func (d rawDocument) functionDocument() (FunctionDocument, error) {
+	fields := d.fields
+	project, hasProject := fields["project"]
+	if hasProject {
+		if _, ok := fields["project_id"]; ok {
+			return FunctionDocument{}, fmt.Errorf("%s: set either project or project_id, not both", d.describe())
+		}
+		name, ok := project.(string)
+		if !ok || name == "" {
+			return FunctionDocument{}, fmt.Errorf("%s: project must be the name of a project", d.describe())
+		}
+		project = name
+
+		// project isn't part of the API definition, the id is filled in once the name is resolved
+		fields = make(map[string]interface{}, len(d.fields))
+		for k, v := range d.fields {
+			fields[k] = v
+		}
+		delete(fields, "project")
+		fields["project_id"] = ""
+	}
+
+	jsonData, err := json.Marshal(fields)
+	if err != nil {
+		return FunctionDocument{}, fmt.Errorf("%s: error converting to JSON: %w", d.describe(), err)
+	}
+
+	var body openapi_chaos_client.FunctionBody
+	if err := json.Unmarshal(jsonData, &body); err != nil {
+		return FunctionDocument{}, fmt.Errorf("%s: error parsing JSON to config: %w", d.describe(), err)
+	}
+
+	document := FunctionDocument{Source: d.source, Index: d.index, Overlays: d.overlayPositions, Body: body}
+	if hasProject {
+		document.Project = project.(string)
+	}
+	return document, nil
+}
+
+// describe is the position of the definition including its overlays
+func (d rawDocument) describe() string {
+	return FunctionDocument{Source: d.source, Index: d.index, Overlays: d.overlayPositions}.Position()
+}
+
+// parseRawDocuments decodes every document of a yaml stream, empty documents are skipped. Every
+// document is passed to interpolate, if it isn't nil, before it is decoded.
+func parseRawDocuments(source FunctionSource, interpolate func(doc *yaml.Node)) ([]rawDocument, error) {
+	var documents []rawDocument
+	decoder := yaml.NewDecoder(bytes.NewReader(source.Content))
+
+	for index := 1; ; index++ {
+		var doc yaml.Node
+		err := decoder.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s (document %d): error parsing YAML: %w", source.Name, index, err)
+		}
+		if interpolate != nil {
+			interpolate(&doc)
+		}
+
+		var raw interface{}
+		if err := doc.Decode(&raw); err != nil {
+			return nil, fmt.Errorf("%s (document %d): error parsing YAML: %w", source.Name, index, err)
+		}
+		if raw == nil {
+			continue
+		}
+
+		fields, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s (document %d): function definition must be a mapping", source.Name, index)
+		}
+		documents = append(documents, rawDocument{source: source.Name, arg: source.Arg, index: index, fields: fields})
+	}
+
+	return documents, nil
+}
+
+// mergeFields deep-merges overlay into base. Mappings are merged field by field, lists and other
+// values are replaced and a null value removes the field.
+func mergeFields(base map[string]interface{}, overlay map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(base))
+	for k, v := range base {
+		merged[k] = v
+	}
+
+	for k, v := range overlay {
+		if v == nil {
+			delete(merged, k)
+			continue
+		}
+		baseMap, baseOk := merged[k].(map[string]interface{})
+		overlayMap, overlayOk := v.(map[string]interface{})
+		if baseOk && overlayOk {
+			merged[k] = mergeFields(baseMap, overlayMap)
+			continue
+		}
+		merged[k] = v
+	}
+	return merged
+}
+
+// projectOverlay returns overlay with a null project_id when it sets project, or a null project when
+// it sets project_id, so merging it into base moves the function to the overlay's project
+func projectOverlay(base map[string]interface{}, overlay map[string]interface{}) map[string]interface{} {
+	replaced := ""
+	if _, ok := overlay["project"]; ok {
+		replaced = "project_id"
+	} else if _, ok := overlay["project_id"]; ok {
+		replaced = "project"
+	}
+	if _, ok := base[replaced]; replaced == "" || !ok {
+		return overlay
+	}
+
+	// a null value removes the field when merged
+	fields := make(map[string]interface{}, len(overlay)+1)
+	for k, v := range overlay {
+		fields[k] = v
+	}
+	fields[replaced] = nil
+	return fields
+}
+
+// FunctionBodies returns the definitions of documents
+func FunctionBodies(documents []FunctionDocument) []openapi_chaos_client.FunctionBody {
+	bodies := make([]openapi_chaos_client.FunctionBody, 0, len(documents))
+	for _, doc := range documents {
+		bodies = append(bodies, doc.Body)
+	}
+	return bodies
+}
@@ -0,0 +1,175 @@
+package helpers
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFunctionFile(t *testing.T, dir string, name string, content string) string {
+	t.Helper()
+	file := filepath.Join(dir, name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(file), 0700))
+	require.NoError(t, os.WriteFile(file, []byte(content), 0600))
+	return file
+}
+
+// namedFunction returns a valid function definition with the given name
+func namedFunction(name string) string {
+	return strings.Replace(validFunction, "name: api\n", "name: "+name+"\n", 1)
+}
+
+func TestParseFunctionDocuments(t *testing.T) {
+	api := strings.Replace(validFunction, "description: public api\n", "description: |\n  first line\n  ---\n  not a separator\n", 1)
+	content := "# leading comment\n---\n" + api + "---\n---\n" + namedFunction("worker")
+	docs, err := ParseFunctionDocuments(FunctionSource{Name: "functions.yaml", Content: []byte(content)})
+	require.NoError(t, err)
+	require.Len(t, docs, 2, "empty documents are skipped and --- inside a block scalar doesn't split")
+
+	assert.Equal(t, "api", docs[0].Body.Name)
+	assert.Equal(t, "first line\n---\nnot a separator\n", docs[0].Body.Description)
+	assert.Equal(t, 1, docs[0].Index)
+	assert.Equal(t, "worker", docs[1].Body.Name)
+	assert.Equal(t, 3, docs[1].Index)
+	assert.Equal(t, "functions.yaml (document 3)", docs[1].Position())
+}
+
+func TestParseFunctionDocumentsError(t *testing.T) {
+	content := validFunction + "---\nname: [worker\n"
+	_, err := ParseFunctionDocuments(FunctionSource{Name: "functions.yaml", Content: []byte(content)})
+	require.Error(t, err)
+	assert.True(t, strings.HasPrefix(err.Error(), "functions.yaml (document 2)"), err.Error())
+}
+
+func TestLoadFunctionDocuments(t *testing.T) {
+	dir := t.TempDir()
+	writeFunctionFile(t, dir, "b.yaml", namedFunction("b"))
+	writeFunctionFile(t, dir, "a.yml", namedFunction("a1")+"---\n"+namedFunction("a2"))
+	writeFunctionFile(t, dir, "nested/c.yaml", namedFunction("c"))
+	writeFunctionFile(t, dir, "notes.txt", namedFunction("ignored"))
+	single := writeFunctionFile(t, t.TempDir(), "single.yaml", namedFunction("single"))
+
+	names := func(docs []FunctionDocument) []string {
+		var n []string
+		for _, doc := range docs {
+			n = append(n, doc.Body.Name)
+		}
+		return n
+	}
+
+	t.Run("directory", func(t *testing.T) {
+		docs, err := LoadFunctionDocuments([]string{dir}, nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a1", "a2", "b", "c"}, names(docs))
+		assert.Equal(t, filepath.Join(dir, "nested", "c.yaml"), docs[3].Source)
+	})
+
+	t.Run("glob", func(t *testing.T) {
+		docs, err := LoadFunctionDocuments([]string{filepath.Join(dir, "*.yaml")}, nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"b"}, names(docs))
+	})
+
+	t.Run("repeated files and stdin", func(t *testing.T) {
+		stdin := strings.NewReader(namedFunction("piped"))
+		docs, err := LoadFunctionDocuments([]string{single, StdinSource, single}, stdin, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"single", "piped"}, names(docs), "a file given twice is read once")
+		assert.Equal(t, "<stdin>", docs[1].Source)
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		_, err := LoadFunctionDocuments([]string{filepath.Join(dir, "*.json")}, nil, nil)
+		assert.ErrorContains(t, err, "no function files match")
+	})
+
+	t.Run("no definitions", func(t *testing.T) {
+		_, err := LoadFunctionDocuments([]string{StdinSource}, strings.NewReader("---\n# nothing\n"), nil)
+		assert.ErrorContains(t, err, "no function definitions found")
+	})
+}
+
+func TestLoadFunctionDocumentsOverlays(t *testing.T) {
+	dir := t.TempDir()
+	base := writeFunctionFile(t, dir, "base.yaml", strings.Replace(validFunction, "image: nginx:latest", "image: ${IMAGE}", 1)+"---\n"+namedFunction("worker"))
+	overlay := writeFunctionFile(t, dir, "overlays/prod.yaml", `name: api
+project_id: ${PROD_PROJECT}
+size:
+  cpu: 512
+routes: ~
+deployments:
+  - location:
+      provider_id: 5c7c7a39-3b1b-4e45-9c55-8e2a6a1e3c6d
+    replicas: {min: 2, max: 10, affinity: {cluster: true, cloud: false}}
+`)
+
+	vars := Variables{"IMAGE": "nginx:1.27", "PROD_PROJECT": "9a0e1b2c-3b1b-4e45-9c55-8e2a6a1e3c6d"}
+	docs, err := LoadFunctionDocuments([]string{base, overlay}, nil, vars)
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+
+	api := docs[0].Body
+	assert.Equal(t, "nginx:1.27", api.Image)
+	assert.Equal(t, vars["PROD_PROJECT"], api.ProjectId, "overlay values replace the base")
+	assert.Equal(t, int32(512), api.Size.Cpu)
+	assert.Equal(t, int32(256), api.Size.Memory, "mappings are merged field by field")
+	assert.Nil(t, api.Routes, "null removes a field")
+	require.Len(t, api.Deployments, 1)
+	assert.Equal(t, int32(10), api.Deployments[0].Replicas.Max, "lists are replaced")
+	assert.Empty(t, api.Deployments[0].Location.Continent)
+	assert.Len(t, api.Secrets, 1, "fields missing from the overlay are kept")
+	assert.Equal(t, base+" (document 1) with overlays "+overlay+" (document 1)", docs[0].Position())
+	assert.Equal(t, "worker", docs[1].Body.Name)
+	assert.Empty(t, docs[1].Overlays)
+
+	_, err = LoadFunctionDocuments([]string{base}, nil, nil)
+	assert.ErrorContains(t, err, "variable IMAGE is not set")
+
+	duplicate := writeFunctionFile(t, dir, "duplicate.yaml", validFunction+"---\n"+validFunction)
+	_, err = LoadFunctionDocuments([]string{duplicate}, nil, nil)
+	assert.ErrorContains(t, err, "function api is defined more than once")
+}
+
+func TestLoadFunctionDocumentsDuplicates(t *testing.T) {
+	dir := t.TempDir()
+	base := writeFunctionFile(t, dir, "functions/api.yaml", validFunction)
+	writeFunctionFile(t, dir, "functions/copy.yaml", validFunction)
+	overlay := writeFunctionFile(t, dir, "overlays/a.yaml", "name: api\nimage: nginx:1.27\n")
+	writeFunctionFile(t, dir, "overlays/b.yaml", "name: api\nimage: nginx:1.28\n")
+
+	_, err := LoadFunctionDocuments([]string{filepath.Join(dir, "functions")}, nil, nil)
+	assert.ErrorContains(t, err, "function api is defined more than once, in "+base+" (document 1) and "+filepath.Join(dir, "functions/copy.yaml")+" (document 1)", "files of a directory can't overlay each other")
+
+	_, err = LoadFunctionDocuments([]string{base, filepath.Join(dir, "overlays/*.yaml")}, nil, nil)
+	assert.ErrorContains(t, err, "function api is defined more than once", "files of a glob can't overlay each other")
+
+	docs, err := LoadFunctionDocuments([]string{base, overlay, filepath.Join(dir, "overlays/b.yaml")}, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	assert.Equal(t, "nginx:1.28", docs[0].Body.Image, "later paths overlay earlier ones")
+}
+
+func TestLoadFunctionDocumentsProject(t *testing.T) {
+	dir := t.TempDir()
+	byName := strings.Replace(validFunction, "project_id: b4b3c1d0-3b1b-4e45-9c55-8e2a6a1e3c6d", "project: staging", 1)
+	base := writeFunctionFile(t, dir, "base.yaml", byName)
+
+	docs, err := LoadFunctionDocuments([]string{base}, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "staging", docs[0].Project)
+	assert.Empty(t, docs[0].Body.ProjectId)
+
+	overlay := writeFunctionFile(t, dir, "prod.yaml", "name: api\nproject: prod\n")
+	docs, err = LoadFunctionDocuments([]string{writeFunctionFile(t, dir, "id.yaml", validFunction), overlay}, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "prod", docs[0].Project, "an overlay project replaces the base project_id")
+	assert.Empty(t, docs[0].Body.ProjectId)
+
+	both := writeFunctionFile(t, dir, "both.yaml", "project: prod\n"+validFunction)
+	_, err = LoadFunctionDocuments([]string{both}, nil, nil)
+	assert.ErrorContains(t, err, "set either project or project_id, not both")
+}
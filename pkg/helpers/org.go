@@ -2,7 +2,6 @@ package helpers
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"log/slog"
 	"os"
@@ -66,7 +65,7 @@ func PaginateOrganisations(printer *utils.Printer, ctx context.Context, qc *clie
 		if data, ok := resData.(map[string]interface{}); ok {
 			if innerData, ok := data["data"].(map[string]interface{}); ok {
 				if nameErr, ok := innerData["name"].(string); ok {
-					return nil, printer.RenderError("unable to list organisations", errors.New(nameErr))
+					return nil, printer.RenderError("unable to list organisations", client.NewResponseError(nameErr, err))
 				}
 			}
 		}
@@ -101,7 +100,7 @@ func PaginateOrganisations(printer *utils.Printer, ctx context.Context, qc *clie
 			if data, ok := resData.(map[string]interface{}); ok {
 				if innerData, ok := data["data"].(map[string]interface{}); ok {
 					if nameErr, ok := innerData["name"].(string); ok {
-						return nil, printer.RenderError("unable to list projects", errors.New(nameErr))
+						return nil, printer.RenderError("unable to list projects", client.NewResponseError(nameErr, err))
 					}
 				}
 			}
@@ -2,7 +2,6 @@ package helpers
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"log/slog"
 	"os"
@@ -67,7 +66,7 @@ func PaginateProjects(printer *utils.Printer, ctx context.Context, qc *client.Qe
 		if data, ok := resData.(map[string]interface{}); ok {
 			if innerData, ok := data["data"].(map[string]interface{}); ok {
 				if nameErr, ok := innerData["name"].(string); ok {
-					return nil, printer.RenderError("unable to list projects", errors.New(nameErr))
+					return nil, printer.RenderError("unable to list projects", client.NewResponseError(nameErr, err))
 				}
 			}
 		}
@@ -100,7 +99,7 @@ func PaginateProjects(printer *utils.Printer, ctx context.Context, qc *client.Qe
 			if data, ok := resData.(map[string]interface{}); ok {
 				if innerData, ok := data["data"].(map[string]interface{}); ok {
 					if nameErr, ok := innerData["name"].(string); ok {
-						return nil, printer.RenderError("unable to list projects", errors.New(nameErr))
+						return nil, printer.RenderError("unable to list projects", client.NewResponseError(nameErr, err))
 					}
 				}
 			}
@@ -123,11 +122,11 @@ func ValidateProjectFlags(cmd *cobra.Command) error {
 	project, _ := cmd.Flags().GetString("project")
 
 	if projectID == "" && project == "" {
-		return errors.New("either --project-id or --project must be provided")
+		return utils.UsageError("either --project-id or --project must be provided")
 	}
 
 	if projectID != "" && project != "" {
-		return errors.New("cannot specify both --project-id and --project")
+		return utils.UsageError("cannot specify both --project-id and --project")
 	}
 
 	return nil
@@ -150,3 +149,65 @@ func GetProjectID(cmd *cobra.Command, qc *client.QernalAPIClient) (string, error
 
 	return project.Id, nil
 }
+
+// ResolveProject returns the id of a project given either its id or its name
+func ResolveProject(qc *client.QernalAPIClient, project string) (string, error) {
+	if uuidPattern.MatchString(project) {
+		return project, nil
+	}
+
+	match, err := qc.GetProjectByName(project)
+	if err != nil {
+		return "", charm.RenderError("❌", err)
+	}
+	return match.Id, nil
+}
+
+// ResolveDocumentProjects fills in the project id of every function definition. projectID overrides
+// the projects of the definitions, otherwise project names are resolved with lookup. Unless
+// projectID is set the definitions must all be in the same project.
+func ResolveDocumentProjects(documents []FunctionDocument, projectID string, lookup func(name string) (string, error)) error {
+	if projectID != "" {
+		for i := range documents {
+			documents[i].Body.ProjectId = projectID
+		}
+		return nil
+	}
+
+	resolved := map[string]string{}
+	for i := range documents {
+		doc := &documents[i]
+		if doc.Project == "" {
+			if doc.Body.ProjectId == "" {
+				return utils.UsageError(fmt.Sprintf("%s: function %s has no project, set project or project_id or pass --project", doc.Position(), doc.Body.Name))
+			}
+			continue
+		}
+
+		id, ok := resolved[doc.Project]
+		if !ok {
+			var err error
+			if id, err = lookup(doc.Project); err != nil {
+				return fmt.Errorf("%s: %w", doc.Position(), err)
+			}
+			resolved[doc.Project] = id
+		}
+		doc.Body.ProjectId = id
+	}
+
+	for _, doc := range documents {
+		if first := documents[0]; doc.Body.ProjectId != first.Body.ProjectId {
+			return utils.UsageError(fmt.Sprintf("function %s is in project %s but function %s is in project %s, pass --project or --project-id to deploy them to one project",
+				first.Body.Name, documentProject(first), doc.Body.Name, documentProject(doc)))
+		}
+	}
+	return nil
+}
+
+// documentProject is the project of a definition as it was written
+func documentProject(doc FunctionDocument) string {
+	if doc.Project != "" {
+		return doc.Project
+	}
+	return doc.Body.ProjectId
+}
@@ -0,0 +1,59 @@
+package helpers
+
+import (
+	"errors"
+	"testing"
+
+	openapi_chaos_client "github.com/qernal/openapi-chaos-go-client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func projectDocument(name string, project string, projectID string) FunctionDocument {
+	return FunctionDocument{
+		Source:  "functions.yaml",
+		Index:   1,
+		Project: project,
+		Body:    openapi_chaos_client.FunctionBody{Name: name, ProjectId: projectID},
+	}
+}
+
+func TestResolveDocumentProjects(t *testing.T) {
+	projects := map[string]string{
+		"staging": "b4b3c1d0-3b1b-4e45-9c55-8e2a6a1e3c6d",
+		"prod":    "c5c4d2e1-3b1b-4e45-9c55-8e2a6a1e3c6d",
+	}
+	lookups := 0
+	lookup := func(name string) (string, error) {
+		lookups++
+		if id, ok := projects[name]; ok {
+			return id, nil
+		}
+		return "", errors.New("unable to find project with name " + name)
+	}
+
+	documents := []FunctionDocument{
+		projectDocument("api", "staging", ""),
+		projectDocument("worker", "staging", ""),
+		projectDocument("cron", "", projects["staging"]),
+	}
+	require.NoError(t, ResolveDocumentProjects(documents, "", lookup))
+	for _, doc := range documents {
+		assert.Equal(t, projects["staging"], doc.Body.ProjectId)
+	}
+	assert.Equal(t, 1, lookups, "names are resolved once")
+
+	mixed := []FunctionDocument{projectDocument("api", "staging", ""), projectDocument("worker", "prod", "")}
+	err := ResolveDocumentProjects(mixed, "", lookup)
+	assert.EqualError(t, err, "function api is in project staging but function worker is in project prod, pass --project or --project-id to deploy them to one project")
+
+	require.NoError(t, ResolveDocumentProjects(mixed, projects["prod"], lookup), "an override replaces every project")
+	assert.Equal(t, projects["prod"], mixed[0].Body.ProjectId)
+	assert.Equal(t, projects["prod"], mixed[1].Body.ProjectId)
+
+	err = ResolveDocumentProjects([]FunctionDocument{projectDocument("api", "", "")}, "", lookup)
+	assert.EqualError(t, err, "functions.yaml (document 1): function api has no project, set project or project_id or pass --project")
+
+	err = ResolveDocumentProjects([]FunctionDocument{projectDocument("api", "missing", "")}, "", lookup)
+	assert.EqualError(t, err, "functions.yaml (document 1): unable to find project with name missing")
+}
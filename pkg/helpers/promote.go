@@ -0,0 +1,52 @@
+package helpers
+
+import (
+	"fmt"
+	"slices"
+
+	openapi_chaos_client "github.com/qernal/openapi-chaos-go-client"
+)
+
+// PromoteFunction builds the definition of function in project projectID. Secret references are
+// remapped to the current revision of the secret with the same name in secrets, the secrets of the
+// target project keyed by name. A problem is returned for every secret the target project lacks or
+// that isn't an environment secret there.
+func PromoteFunction(function openapi_chaos_client.Function, projectID string, secrets map[string]openapi_chaos_client.SecretMetaResponse) (openapi_chaos_client.FunctionBody, []SecretProblem) {
+	body := FunctionToBody(function)
+	body.ProjectId = projectID
+	body.Secrets = slices.Clone(body.Secrets)
+
+	var problems []SecretProblem
+	for i, env := range body.Secrets {
+		problem := SecretProblem{
+			Source:    fmt.Sprintf("project %s", function.ProjectId),
+			Function:  function.Name,
+			Env:       env.Name,
+			Reference: env.Reference,
+		}
+
+		name := ""
+		if parts := secretRefParts.FindStringSubmatch(env.Reference); parts != nil {
+			name = parts[2]
+		} else if parts := shortSecretRef.FindStringSubmatch(env.Reference); parts != nil {
+			name = parts[1]
+		} else {
+			problem.Message = fmt.Sprintf("reference %q isn't in the form projects:<project id>/<SECRET_NAME>@<revision>", env.Reference)
+			problems = append(problems, problem)
+			continue
+		}
+
+		secret, ok := secrets[name]
+		switch {
+		case !ok:
+			problem.Message = fmt.Sprintf("secret %s doesn't exist in project %s", name, projectID)
+		case secret.Type != openapi_chaos_client.SECRETMETATYPE_ENVIRONMENT:
+			problem.Message = fmt.Sprintf("secret %s is a %s secret in project %s, only environment secrets can be used as env vars", name, secret.Type, projectID)
+		default:
+			body.Secrets[i].Reference = fmt.Sprintf("projects:%s/%s@%d", projectID, secret.Name, secret.Revision)
+			continue
+		}
+		problems = append(problems, problem)
+	}
+	return body, problems
+}
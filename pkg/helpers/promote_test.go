@@ -0,0 +1,49 @@
+package helpers
+
+import (
+	"testing"
+
+	openapi_chaos_client "github.com/qernal/openapi-chaos-go-client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPromoteFunction(t *testing.T) {
+	function := openapi_chaos_client.Function{
+		Id:        "6f1c9b2e-1d4f-4c8a-9a57-2f0c3e7d8b91",
+		ProjectId: secretsProjectID,
+		Name:      "api",
+		Image:     "nginx:1.27",
+		Revision:  "4",
+		Secrets: []openapi_chaos_client.FunctionEnv{
+			{Name: "DATABASE_URL", Reference: "projects:" + secretsProjectID + "/DATABASE_URL@3"},
+			{Name: "API_KEY", Reference: "projects:" + secretsProjectID + "/API_KEY@1"},
+		},
+	}
+	secrets := map[string]openapi_chaos_client.SecretMetaResponse{
+		"DATABASE_URL": {Name: "DATABASE_URL", Type: openapi_chaos_client.SECRETMETATYPE_ENVIRONMENT, Revision: 7},
+		"API_KEY":      {Name: "API_KEY", Type: openapi_chaos_client.SECRETMETATYPE_ENVIRONMENT, Revision: 2},
+	}
+
+	body, problems := PromoteFunction(function, otherProjectID, secrets)
+	assert.Empty(t, problems)
+	assert.Equal(t, otherProjectID, body.ProjectId)
+	assert.Equal(t, "nginx:1.27", body.Image)
+	assert.Equal(t, []openapi_chaos_client.FunctionEnv{
+		{Name: "DATABASE_URL", Reference: "projects:" + otherProjectID + "/DATABASE_URL@7"},
+		{Name: "API_KEY", Reference: "projects:" + otherProjectID + "/API_KEY@2"},
+	}, body.Secrets)
+	assert.Equal(t, "projects:"+secretsProjectID+"/DATABASE_URL@3", function.Secrets[0].Reference, "the live function is left untouched")
+
+	secrets = map[string]openapi_chaos_client.SecretMetaResponse{
+		"API_KEY": {Name: "API_KEY", Type: openapi_chaos_client.SECRETMETATYPE_REGISTRY, Revision: 1},
+	}
+	_, problems = PromoteFunction(function, otherProjectID, secrets)
+	var messages []string
+	for _, problem := range problems {
+		messages = append(messages, problem.Error())
+	}
+	assert.Equal(t, []string{
+		"project " + secretsProjectID + ": function api, env DATABASE_URL: secret DATABASE_URL doesn't exist in project " + otherProjectID,
+		"project " + secretsProjectID + ": function api, env API_KEY: secret API_KEY is a registry secret in project " + otherProjectID + ", only environment secrets can be used as env vars",
+	}, messages)
+}
@@ -0,0 +1,245 @@
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/qernal/cli-qernal/pkg/client"
+	"github.com/qernal/cli-qernal/pkg/utils"
+	openapi_chaos_client "github.com/qernal/openapi-chaos-go-client"
+)
+
+// staleLookback is how far before a rollout started logs are read, containers that logged in it
+// were already running and belong to an earlier revision
+const staleLookback = 10 * time.Minute
+
+// RolloutStatus is what is known about a function rollout. The API doesn't report rollouts and
+// logs can't be filtered by revision or location, so readiness is inferred from telemetry: a
+// container counts as a replica of the rollout if it first logged, or had a platform event, after
+// the rollout started, and it is placed in a deployment by the location labels of its logs.
+type RolloutStatus struct {
+	Name         string `json:"name"`
+	Revision     string `json:"revision"`
+	WantRevision string `json:"want_revision"`
+	// Started is when the wanted revision was applied, zero if that isn't known and the function was
+	// already at the revision when the wait started
+	Started   time.Time        `json:"started"`
+	Locations []LocationStatus `json:"locations"`
+	// Unplaced is the number of new containers whose logs don't say which deployment they run in
+	Unplaced int `json:"unplaced"`
+}
+
+// LocationStatus is the rollout of one deployment of a function
+type LocationStatus struct {
+	Location string `json:"location"`
+	// Replicas is the number of replicas the deployment runs at least
+	Replicas int `json:"replicas"`
+	// Containers is the number of containers that started reporting in the deployment since the rollout started
+	Containers int `json:"containers"`
+}
+
+// Ready reports whether the wanted revision is serving with a description of the progress
+func (s RolloutStatus) Ready() (bool, string) {
+	if s.Revision != s.WantRevision {
+		return false, fmt.Sprintf("function %s is at revision %s, waiting for revision %s", s.Name, s.Revision, s.WantRevision)
+	}
+
+	replicas, containers := 0, 0
+	var waiting []string
+	for _, location := range s.Locations {
+		replicas += location.Replicas
+		containers += min(location.Containers, location.Replicas)
+		if location.Containers < location.Replicas {
+			waiting = append(waiting, fmt.Sprintf("%s %d of %d", location.Location, location.Containers, location.Replicas))
+		}
+	}
+
+	switch {
+	case replicas == 0:
+		// every deployment scales to zero, there is nothing to wait for
+		return true, fmt.Sprintf("function %s is at revision %s and scaled to zero", s.Name, s.Revision)
+	case len(waiting) > 0:
+		message := fmt.Sprintf("function %s revision %s: %d of %d replicas reporting, waiting for %s", s.Name, s.Revision, containers, replicas, strings.Join(waiting, ", "))
+		if s.Unplaced > 0 {
+			message += fmt.Sprintf(" (%d replicas reporting without a location)", s.Unplaced)
+		}
+		return false, message
+	default:
+		return true, fmt.Sprintf("function %s is serving revision %s with %d replicas in %d locations", s.Name, s.Revision, replicas, len(s.Locations))
+	}
+}
+
+// locationName describes a deployment location by its most specific part
+func locationName(l openapi_chaos_client.Location) string {
+	for _, value := range []*string{l.City, l.Country, l.Continent} {
+		if value != nil && *value != "" {
+			return *value
+		}
+	}
+	return l.ProviderId
+}
+
+// locationMatch scores how specifically labels identify location, 0 if they don't
+func locationMatch(l openapi_chaos_client.Location, labels map[string]bool) int {
+	best := 0
+	for score, value := range []*string{&l.ProviderId, l.Continent, l.Country, l.City} {
+		if value != nil && *value != "" && labels[strings.ToLower(*value)] {
+			best = score + 1
+		}
+	}
+	return best
+}
+
+// rolloutLocations counts the containers of every deployment that started reporting at or after
+// started. Containers with logs from before started belong to an earlier revision, even if they
+// are still draining. New containers are placed in the deployment their log labels match most
+// specifically, or in the only deployment if there is just one.
+func rolloutLocations(deployments []openapi_chaos_client.FunctionDeployment, logs []openapi_chaos_client.Log, started time.Time) ([]LocationStatus, int) {
+	type container struct {
+		first  time.Time
+		labels map[string]bool
+	}
+	containers := map[string]*container{}
+	for _, log := range logs {
+		if log.Container == nil || log.Log == nil || log.Log.Timestamp == nil {
+			continue
+		}
+		at, err := time.Parse(time.RFC3339Nano, *log.Log.Timestamp)
+		if err != nil {
+			continue
+		}
+
+		c, ok := containers[*log.Container]
+		if !ok {
+			c = &container{first: at, labels: map[string]bool{}}
+			containers[*log.Container] = c
+		}
+		if at.Before(c.first) {
+			c.first = at
+		}
+		for _, label := range log.Log.Labels {
+			c.labels[strings.ToLower(label)] = true
+		}
+	}
+
+	locations := make([]LocationStatus, len(deployments))
+	for i, deployment := range deployments {
+		locations[i] = LocationStatus{Location: locationName(deployment.Location), Replicas: int(deployment.Replicas.Min)}
+	}
+
+	unplaced := 0
+	for _, c := range containers {
+		if c.first.Before(started) {
+			continue
+		}
+
+		placed, best := -1, 0
+		for i, deployment := range deployments {
+			if score := locationMatch(deployment.Location, c.labels); score > best {
+				placed, best = i, score
+			}
+		}
+		if placed < 0 && len(deployments) == 1 {
+			placed = 0
+		}
+		if placed < 0 {
+			unplaced++
+			continue
+		}
+		locations[placed].Containers++
+	}
+	return locations, unplaced
+}
+
+// revisionStarted returns when revision of a function was last applied from this machine, or since if
+// it wasn't
+func revisionStarted(functionID string, revision string, since time.Time) time.Time {
+	snapshots, err := ReadSnapshots(functionID)
+	if err != nil {
+		return since
+	}
+	for i := len(snapshots) - 1; i >= 0; i-- {
+		if snapshots[i].Revision == revision {
+			return snapshots[i].AppliedAt
+		}
+	}
+	return since
+}
+
+// GetRolloutStatus returns the rollout of revision of a function. The rollout started when the
+// revision was applied from this machine. If it wasn't, since is the last time the function was seen
+// at another revision, or zero if it hasn't been. A zero start counts every container that is still
+// reporting, as a function already at the revision may have been serving it for a while. An empty
+// revision waits for the function's current revision.
+func GetRolloutStatus(ctx context.Context, qc *client.QernalAPIClient, printer *utils.Printer, functionID string, revision string, since time.Time) (RolloutStatus, error) {
+	qFunc, httpRes, err := qc.FunctionsAPI.FunctionsGet(ctx, functionID).Execute()
+	if err != nil {
+		return RolloutStatus{}, FunctionRequestError(printer, "unable to find function", httpRes, err)
+	}
+
+	status := RolloutStatus{
+		Name:         qFunc.Name,
+		Revision:     qFunc.Revision,
+		WantRevision: revision,
+	}
+	if status.WantRevision == "" {
+		status.WantRevision = qFunc.Revision
+	}
+	status.Started = revisionStarted(functionID, status.WantRevision, since)
+	status.Locations, _ = rolloutLocations(qFunc.Deployments, nil, status.Started)
+	if status.Revision != status.WantRevision {
+		return status, nil
+	}
+
+	// containers of an earlier revision have drained staleLookback after a rollout, so a rollout
+	// that started before that only needs recent logs
+	from := status.Started.Add(-staleLookback)
+	if recent := time.Now().Add(-staleLookback); status.Started.Before(recent) {
+		from = recent
+	}
+	// logs lag behind, failures are retried on the next poll
+	logs, err := listLogs(ctx, qc, qFunc.ProjectId, functionID, from, time.Now())
+	if err != nil {
+		logRolloutError(printer, err)
+		return status, nil
+	}
+	status.Locations, status.Unplaced = rolloutLocations(qFunc.Deployments, logs, status.Started)
+	return status, nil
+}
+
+// listLogs returns every log and event of a function between after and before, reading all pages
+func listLogs(ctx context.Context, qc *client.QernalAPIClient, projectID string, functionID string, after time.Time, before time.Time) ([]openapi_chaos_client.Log, error) {
+	from := after.UTC().Format(time.RFC3339)
+	to := before.UTC().Format(time.RFC3339)
+	timestamps := openapi_chaos_client.LogsListFTimestampsParameter{After: &from, Before: &to}
+
+	pageSize := int32(500)
+	var logs []openapi_chaos_client.Log
+	for page := int32(1); ; page++ {
+		previous := page - 1
+		resp, httpRes, err := qc.LogsAPI.LogsList(ctx).
+			FProject(projectID).
+			FFunction(functionID).
+			FTimestamps(timestamps).
+			Page(openapi_chaos_client.OrganisationsListPageParameter{
+				Size:   &pageSize,
+				Before: &previous,
+				After:  &page,
+			}).Execute()
+		if err != nil {
+			resData, _ := client.ParseResponseData(httpRes)
+			return nil, fmt.Errorf("unable to list logs: %w, detail: %v", err, resData)
+		}
+		logs = append(logs, resp.Data...)
+		if page >= resp.Meta.Pages {
+			return logs, nil
+		}
+	}
+}
+
+func logRolloutError(printer *utils.Printer, err error) {
+	printer.Logger.Debug("unable to get function logs, request failed", slog.String("error", err.Error()))
+}
@@ -0,0 +1,176 @@
+package helpers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/qernal/cli-qernal/pkg/client"
+	openapi_chaos_client "github.com/qernal/openapi-chaos-go-client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRolloutStatusReady(t *testing.T) {
+	testCases := []struct {
+		name     string
+		status   RolloutStatus
+		ready    bool
+		expected string
+	}{
+		{
+			name:     "older revision",
+			status:   RolloutStatus{Name: "api", Revision: "3", WantRevision: "4", Locations: []LocationStatus{{Location: "London", Replicas: 2}}},
+			expected: "function api is at revision 3, waiting for revision 4",
+		},
+		{
+			name: "replicas starting",
+			status: RolloutStatus{Name: "api", Revision: "4", WantRevision: "4", Unplaced: 1, Locations: []LocationStatus{
+				{Location: "London", Replicas: 2, Containers: 1},
+				{Location: "Paris", Replicas: 1, Containers: 1},
+			}},
+			expected: "function api revision 4: 2 of 3 replicas reporting, waiting for London 1 of 2 (1 replicas reporting without a location)",
+		},
+		{
+			name: "one location over its minimum",
+			status: RolloutStatus{Name: "api", Revision: "4", WantRevision: "4", Locations: []LocationStatus{
+				{Location: "London", Replicas: 1, Containers: 2},
+				{Location: "Paris", Replicas: 1},
+			}},
+			expected: "function api revision 4: 1 of 2 replicas reporting, waiting for Paris 0 of 1",
+		},
+		{
+			name: "serving",
+			status: RolloutStatus{Name: "api", Revision: "4", WantRevision: "4", Locations: []LocationStatus{
+				{Location: "London", Replicas: 2, Containers: 3},
+				{Location: "Paris", Replicas: 1, Containers: 1},
+			}},
+			ready:    true,
+			expected: "function api is serving revision 4 with 3 replicas in 2 locations",
+		},
+		{
+			name:     "scaled to zero",
+			status:   RolloutStatus{Name: "api", Revision: "4", WantRevision: "4", Locations: []LocationStatus{{Location: "London"}}},
+			ready:    true,
+			expected: "function api is at revision 4 and scaled to zero",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ready, message := tc.status.Ready()
+			assert.Equal(t, tc.ready, ready)
+			assert.Equal(t, tc.expected, message)
+		})
+	}
+}
+
+func rolloutLog(container string, at time.Time, labels ...string) openapi_chaos_client.Log {
+	timestamp := at.UTC().Format(time.RFC3339Nano)
+	return openapi_chaos_client.Log{
+		Container: &container,
+		Log:       &openapi_chaos_client.LogLog{Timestamp: &timestamp, Labels: labels},
+	}
+}
+
+func rolloutDeployment(provider string, city string, min int32) openapi_chaos_client.FunctionDeployment {
+	return openapi_chaos_client.FunctionDeployment{
+		Location: openapi_chaos_client.Location{ProviderId: provider, City: &city},
+		Replicas: openapi_chaos_client.FunctionReplicas{Min: min, Max: 3},
+	}
+}
+
+func TestRolloutLocationsStaleContainers(t *testing.T) {
+	started := time.Now()
+	deployments := []openapi_chaos_client.FunctionDeployment{rolloutDeployment("qernal", "London", 2)}
+
+	// old containers still drain and log after the rollout started
+	logs := []openapi_chaos_client.Log{
+		rolloutLog("old-1", started.Add(-time.Minute)),
+		rolloutLog("old-1", started.Add(10*time.Second)),
+		rolloutLog("old-2", started.Add(-5*time.Minute)),
+		rolloutLog("old-2", started.Add(20*time.Second)),
+		rolloutLog("new-1", started.Add(15*time.Second)),
+	}
+
+	locations, unplaced := rolloutLocations(deployments, logs, started)
+	assert.Zero(t, unplaced)
+	assert.Equal(t, []LocationStatus{{Location: "London", Replicas: 2, Containers: 1}}, locations)
+
+	status := RolloutStatus{Name: "api", Revision: "4", WantRevision: "4", Locations: locations}
+	ready, _ := status.Ready()
+	assert.False(t, ready, "draining containers of the old revision don't count")
+
+	// without a known start, a function already at the revision counts every reporting container
+	locations, _ = rolloutLocations(deployments, logs, time.Time{})
+	assert.Equal(t, []LocationStatus{{Location: "London", Replicas: 2, Containers: 3}}, locations)
+}
+
+func TestRolloutLocationsMultipleLocations(t *testing.T) {
+	started := time.Now()
+	deployments := []openapi_chaos_client.FunctionDeployment{
+		rolloutDeployment("qernal", "London", 1),
+		rolloutDeployment("qernal", "Paris", 1),
+	}
+
+	// both replicas in one location don't satisfy a spread over two
+	logs := []openapi_chaos_client.Log{
+		rolloutLog("a", started.Add(time.Second), "qernal", "london"),
+		rolloutLog("b", started.Add(time.Second), "qernal", "London"),
+		rolloutLog("c", started.Add(time.Second)),
+	}
+	locations, unplaced := rolloutLocations(deployments, logs, started)
+	assert.Equal(t, 1, unplaced, "containers without location labels can't be placed")
+	assert.Equal(t, []LocationStatus{
+		{Location: "London", Replicas: 1, Containers: 2},
+		{Location: "Paris", Replicas: 1},
+	}, locations)
+	ready, _ := RolloutStatus{Revision: "4", WantRevision: "4", Locations: locations}.Ready()
+	assert.False(t, ready)
+
+	logs = append(logs, rolloutLog("d", started.Add(2*time.Second), "qernal", "paris"))
+	locations, _ = rolloutLocations(deployments, logs, started)
+	ready, _ = RolloutStatus{Revision: "4", WantRevision: "4", Locations: locations}.Ready()
+	assert.True(t, ready)
+}
+
+func TestRevisionStarted(t *testing.T) {
+	defaultDir := historyDir
+	historyDir = t.TempDir()
+	t.Cleanup(func() { historyDir = defaultDir })
+
+	function := testFunction()
+	require.NoError(t, RecordSnapshot(function))
+	since := time.Now().Add(time.Minute)
+
+	applied := revisionStarted(function.Id, function.Revision, since)
+	assert.True(t, applied.Before(since), "the rollout started when the revision was applied")
+	assert.Equal(t, since, revisionStarted(function.Id, "9", since), "unknown revisions start with the wait")
+}
+
+func TestListLogsReadsEveryPage(t *testing.T) {
+	var pages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page[after]")
+		pages = append(pages, page)
+		container := "container-" + page
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(openapi_chaos_client.ListLogResponse{
+			Meta: openapi_chaos_client.PaginationMeta{Results: 3, Pages: 3, Links: openapi_chaos_client.PaginationLinks{}},
+			Data: []openapi_chaos_client.Log{{Container: &container}},
+		})
+	}))
+	defer server.Close()
+
+	qc := client.QernalAPIClient{APIClient: *openapi_chaos_client.NewAPIClient(&openapi_chaos_client.Configuration{
+		Servers: openapi_chaos_client.ServerConfigurations{{URL: server.URL}},
+	})}
+
+	logs, err := listLogs(context.Background(), &qc, "project", "function", time.Now().Add(-time.Hour), time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "2", "3"}, pages)
+	assert.Len(t, logs, 3)
+}
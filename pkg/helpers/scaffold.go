@@ -0,0 +1,145 @@
+package helpers
+
+import (
+	"fmt"
+	"strings"
+
+	openapi_chaos_client "github.com/qernal/openapi-chaos-go-client"
+)
+
+// FunctionSizes are the cpu and memory units offered when scaffolding a function
+var FunctionSizes = []int32{128, 256, 512, 1024, 2048, 4096}
+
+// FunctionTemplate holds the answers used to scaffold a function definition
+type FunctionTemplate struct {
+	Name        string
+	Description string
+	Image       string
+	Type        openapi_chaos_client.FunctionType
+	Port        int32
+	CPU         int32
+	Memory      int32
+	// Routes are the paths of an http function, they accept Methods
+	Routes      []string
+	Methods     []string
+	ScalingType string
+	ScaleLow    int32
+	ScaleHigh   int32
+	Locations   []openapi_chaos_client.Location
+	MinReplicas int32
+	MaxReplicas int32
+	Secrets     []openapi_chaos_client.SecretMetaResponse
+}
+
+// ProviderLocations returns a location for every continent a provider deploys to, or the provider
+// alone if it doesn't list any. Labels describe the locations for prompts.
+func ProviderLocations(providers []openapi_chaos_client.Provider) (locations []openapi_chaos_client.Location, labels []string) {
+	for _, provider := range providers {
+		if len(provider.Locations.Continents) == 0 {
+			locations = append(locations, openapi_chaos_client.Location{ProviderId: provider.Id})
+			labels = append(labels, provider.Name)
+			continue
+		}
+		for _, continent := range provider.Locations.Continents {
+			continent := continent
+			locations = append(locations, openapi_chaos_client.Location{ProviderId: provider.Id, Continent: &continent})
+			labels = append(labels, fmt.Sprintf("%s, %s", provider.Name, continent))
+		}
+	}
+	return locations, labels
+}
+
+// ParseLocation resolves a provider[:continent] flag value, the provider is matched by id or name
+func ParseLocation(providers []openapi_chaos_client.Provider, value string) (openapi_chaos_client.Location, error) {
+	name, continent, hasContinent := strings.Cut(value, ":")
+	for _, provider := range providers {
+		if provider.Id != name && !strings.EqualFold(provider.Name, name) {
+			continue
+		}
+
+		location := openapi_chaos_client.Location{ProviderId: provider.Id}
+		if !hasContinent {
+			return location, nil
+		}
+		for _, known := range provider.Locations.Continents {
+			if strings.EqualFold(known, continent) {
+				location.Continent = &known
+				return location, nil
+			}
+		}
+		return location, fmt.Errorf("provider %s doesn't deploy to %s, choose one of %s", provider.Name, continent, strings.Join(provider.Locations.Continents, ", "))
+	}
+	return openapi_chaos_client.Location{}, fmt.Errorf("unable to find provider %s, see qernal providers list", name)
+}
+
+// Body builds the function definition of a template in a project
+func (t FunctionTemplate) Body(projectID string) openapi_chaos_client.FunctionBody {
+	body := openapi_chaos_client.FunctionBody{
+		ProjectId:   projectID,
+		Version:     "1.0",
+		Name:        t.Name,
+		Description: t.Description,
+		Image:       t.Image,
+		Type:        t.Type,
+		Size:        openapi_chaos_client.FunctionSize{Cpu: t.CPU, Memory: t.Memory},
+		Port:        t.Port,
+		Scaling:     openapi_chaos_client.FunctionScaling{Type: t.ScalingType, Low: t.ScaleLow, High: t.ScaleHigh},
+		Deployments: []openapi_chaos_client.FunctionDeploymentBody{},
+		Secrets:     []openapi_chaos_client.FunctionEnv{},
+		Compliance:  []openapi_chaos_client.FunctionCompliance{},
+	}
+
+	if t.Type == openapi_chaos_client.FUNCTIONTYPE_HTTP {
+		for _, path := range t.Routes {
+			body.Routes = append(body.Routes, openapi_chaos_client.FunctionRoute{
+				Path:    path,
+				Methods: t.Methods,
+				Weight:  100,
+			})
+		}
+	}
+
+	for _, location := range t.Locations {
+		body.Deployments = append(body.Deployments, openapi_chaos_client.FunctionDeploymentBody{
+			Location: location,
+			Replicas: openapi_chaos_client.FunctionReplicas{
+				Min:      t.MinReplicas,
+				Max:      t.MaxReplicas,
+				Affinity: openapi_chaos_client.FunctionReplicasAffinity{Cluster: false, Cloud: false},
+			},
+		})
+	}
+
+	// the short reference is expanded to the secret's current revision when the file is deployed, so
+	// the file doesn't pin the project or revision
+	for _, secret := range t.Secrets {
+		body.Secrets = append(body.Secrets, openapi_chaos_client.FunctionEnv{
+			Name:      secret.Name,
+			Reference: secret.Name,
+		})
+	}
+
+	return body
+}
+
+// ScaffoldFunctions renders function definitions as a multi-document file and validates it
+func ScaffoldFunctions(bodies []openapi_chaos_client.FunctionBody) (string, error) {
+	documents := make([]string, 0, len(bodies))
+	for _, body := range bodies {
+		document, err := FunctionBodyYAML(body)
+		if err != nil {
+			return "", err
+		}
+		documents = append(documents, document)
+	}
+	content := strings.Join(documents, "---\n")
+
+	if problems := ValidateFunctionDocuments("functions.yaml", []byte(content)); len(problems) > 0 {
+		messages := make([]string, 0, len(problems))
+		for _, problem := range problems {
+			messages = append(messages, problem.Message)
+		}
+		return "", fmt.Errorf("the function definition isn't valid: %s", strings.Join(messages, ", "))
+	}
+	return content, nil
+}
@@ -0,0 +1,123 @@
+package helpers
+
+import (
+	"strings"
+	"testing"
+
+	openapi_chaos_client "github.com/qernal/openapi-chaos-go-client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const scaffoldProjectID = "b4b3c1d0-3b1b-4e45-9c55-8e2a6a1e3c6d"
+
+func scaffoldProviders() []openapi_chaos_client.Provider {
+	return []openapi_chaos_client.Provider{
+		{
+			Id:        "5c7c7a39-3b1b-4e45-9c55-8e2a6a1e3c6d",
+			Name:      "qernal",
+			Locations: openapi_chaos_client.ProviderLocations{Continents: []string{"Europe", "North America"}},
+		},
+		{
+			Id:   "6d8d8b4a-3b1b-4e45-9c55-8e2a6a1e3c6d",
+			Name: "edge",
+		},
+	}
+}
+
+func scaffoldTemplate() FunctionTemplate {
+	continent := "Europe"
+	return FunctionTemplate{
+		Name:        "api",
+		Image:       "nginx:latest",
+		Type:        openapi_chaos_client.FUNCTIONTYPE_HTTP,
+		Port:        80,
+		CPU:         256,
+		Memory:      512,
+		Routes:      []string{"/", "/api"},
+		Methods:     []string{"GET", "POST"},
+		ScalingType: "cpu",
+		ScaleLow:    20,
+		ScaleHigh:   70,
+		Locations:   []openapi_chaos_client.Location{{ProviderId: "5c7c7a39-3b1b-4e45-9c55-8e2a6a1e3c6d", Continent: &continent}},
+		MinReplicas: 1,
+		MaxReplicas: 3,
+		Secrets:     []openapi_chaos_client.SecretMetaResponse{{Name: "DATABASE_URL", Revision: 2}},
+	}
+}
+
+func TestProviderLocations(t *testing.T) {
+	locations, labels := ProviderLocations(scaffoldProviders())
+
+	assert.Equal(t, []string{"qernal, Europe", "qernal, North America", "edge"}, labels)
+	require.Len(t, locations, 3)
+	assert.Equal(t, "North America", *locations[1].Continent)
+	assert.Nil(t, locations[2].Continent)
+}
+
+func TestParseLocation(t *testing.T) {
+	providers := scaffoldProviders()
+
+	location, err := ParseLocation(providers, "Qernal:europe")
+	require.NoError(t, err)
+	assert.Equal(t, "5c7c7a39-3b1b-4e45-9c55-8e2a6a1e3c6d", location.ProviderId)
+	assert.Equal(t, "Europe", *location.Continent)
+
+	location, err = ParseLocation(providers, "6d8d8b4a-3b1b-4e45-9c55-8e2a6a1e3c6d")
+	require.NoError(t, err)
+	assert.Equal(t, "6d8d8b4a-3b1b-4e45-9c55-8e2a6a1e3c6d", location.ProviderId)
+	assert.Nil(t, location.Continent)
+
+	_, err = ParseLocation(providers, "qernal:Antarctica")
+	assert.EqualError(t, err, "provider qernal doesn't deploy to Antarctica, choose one of Europe, North America")
+
+	_, err = ParseLocation(providers, "missing")
+	assert.EqualError(t, err, "unable to find provider missing, see qernal providers list")
+}
+
+func TestFunctionTemplateBody(t *testing.T) {
+	body := scaffoldTemplate().Body(scaffoldProjectID)
+
+	assert.Equal(t, scaffoldProjectID, body.ProjectId)
+	assert.Equal(t, openapi_chaos_client.FunctionSize{Cpu: 256, Memory: 512}, body.Size)
+	require.Len(t, body.Routes, 2)
+	assert.Equal(t, "/api", body.Routes[1].Path)
+	assert.Equal(t, []string{"GET", "POST"}, body.Routes[1].Methods)
+	require.Len(t, body.Deployments, 1)
+	assert.Equal(t, int32(3), body.Deployments[0].Replicas.Max)
+	require.Len(t, body.Secrets, 1)
+	assert.Equal(t, "DATABASE_URL", body.Secrets[0].Reference, "references aren't pinned to the project or revision")
+
+	worker := scaffoldTemplate()
+	worker.Type = openapi_chaos_client.FUNCTIONTYPE_WORKER
+	assert.Empty(t, worker.Body(scaffoldProjectID).Routes)
+}
+
+func TestScaffoldFunctions(t *testing.T) {
+	worker := scaffoldTemplate()
+	worker.Name = "worker"
+	worker.Type = openapi_chaos_client.FUNCTIONTYPE_WORKER
+
+	content, err := ScaffoldFunctions([]openapi_chaos_client.FunctionBody{
+		scaffoldTemplate().Body(scaffoldProjectID),
+		worker.Body(scaffoldProjectID),
+	})
+	require.NoError(t, err)
+
+	documents, err := ParseFunctionDocuments(FunctionSource{Name: "functions.yaml", Content: []byte(content)})
+	require.NoError(t, err)
+	require.Len(t, documents, 2)
+	assert.Equal(t, "worker", documents[1].Body.Name)
+
+	bucket := scaffoldTemplate()
+	bucket.Secrets = []openapi_chaos_client.SecretMetaResponse{{Name: "S3_BUCKET", Revision: 1}}
+	content, err = ScaffoldFunctions([]openapi_chaos_client.FunctionBody{bucket.Body(scaffoldProjectID)})
+	require.NoError(t, err, "secret names can contain digits")
+	assert.Contains(t, content, "reference: S3_BUCKET\n")
+
+	invalid := scaffoldTemplate()
+	invalid.ScaleLow = 150
+	_, err = ScaffoldFunctions([]openapi_chaos_client.FunctionBody{invalid.Body(scaffoldProjectID)})
+	require.Error(t, err)
+	assert.True(t, strings.HasPrefix(err.Error(), "the function definition isn't valid: "))
+}
@@ -0,0 +1,181 @@
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/qernal/cli-qernal/pkg/client"
+	"github.com/qernal/cli-qernal/pkg/utils"
+	openapi_chaos_client "github.com/qernal/openapi-chaos-go-client"
+)
+
+var (
+	// secretRefParts splits a secret reference into its project, secret name and revision
+	secretRefParts = regexp.MustCompile(`^projects:([a-z0-9-]{36})/([A-Z0-9_]+)@([0-9]+)$`)
+	// shortSecretRef is a reference to the latest revision of a secret in the function's project
+	shortSecretRef = regexp.MustCompile(`^([A-Z0-9_]+)(@latest)?$`)
+)
+
+// SecretProblem is a secret reference of a function that doesn't resolve to an environment secret
+type SecretProblem struct {
+	Source    string `json:"source"`
+	Function  string `json:"function"`
+	Env       string `json:"env"`
+	Reference string `json:"reference"`
+	Message   string `json:"message"`
+}
+
+func (p SecretProblem) Error() string {
+	return fmt.Sprintf("%s: function %s, env %s: %s", p.Source, p.Function, p.Env, p.Message)
+}
+
+// ProjectSecrets lists the secrets of every project, keyed by project then secret name
+func ProjectSecrets(ctx context.Context, qc *client.QernalAPIClient, printer *utils.Printer, projectIDs []string) (map[string]map[string]openapi_chaos_client.SecretMetaResponse, error) {
+	projects := map[string]map[string]openapi_chaos_client.SecretMetaResponse{}
+	for _, projectID := range projectIDs {
+		if _, ok := projects[projectID]; ok {
+			continue
+		}
+		secrets, err := PaginateSecrets(printer, ctx, qc, 0, projectID)
+		if err != nil {
+			return nil, err
+		}
+		byName := map[string]openapi_chaos_client.SecretMetaResponse{}
+		for _, secret := range secrets {
+			byName[secret.Name] = secret
+		}
+		projects[projectID] = byName
+	}
+	return projects, nil
+}
+
+// functionProjects returns the projects of the functions in documents
+func functionProjects(documents []FunctionDocument) []string {
+	var projectIDs []string
+	for _, doc := range documents {
+		projectIDs = append(projectIDs, doc.Body.ProjectId)
+	}
+	slices.Sort(projectIDs)
+	return slices.Compact(projectIDs)
+}
+
+// CheckFunctionSecrets resolves every secret reference in documents against secrets, keyed by
+// project then name, and returns a problem for each reference that is malformed, points to
+// another project, a missing secret, a secret that isn't an environment secret or an old revision.
+// Short references are left to ExpandSecretReferences.
+func CheckFunctionSecrets(documents []FunctionDocument, secrets map[string]map[string]openapi_chaos_client.SecretMetaResponse) []SecretProblem {
+	var problems []SecretProblem
+	for _, doc := range documents {
+		for _, env := range doc.Body.Secrets {
+			if shortSecretRef.MatchString(env.Reference) {
+				continue
+			}
+			problem := SecretProblem{Source: doc.Position(), Function: doc.Body.Name, Env: env.Name, Reference: env.Reference}
+
+			parts := secretRefParts.FindStringSubmatch(env.Reference)
+			if parts == nil {
+				problem.Message = fmt.Sprintf("reference %q isn't in the form projects:<project id>/<SECRET_NAME>@<revision>", env.Reference)
+				problems = append(problems, problem)
+				continue
+			}
+			projectID, name := parts[1], parts[2]
+			revision, _ := strconv.ParseInt(parts[3], 10, 32)
+
+			secret, ok := secrets[projectID][name]
+			switch {
+			case projectID != doc.Body.ProjectId:
+				problem.Message = fmt.Sprintf("secret %s is in project %s, not the function's project %s", name, projectID, doc.Body.ProjectId)
+			case !ok:
+				problem.Message = fmt.Sprintf("secret %s doesn't exist in project %s", name, projectID)
+			case secret.Type != openapi_chaos_client.SECRETMETATYPE_ENVIRONMENT:
+				problem.Message = fmt.Sprintf("secret %s is a %s secret, only environment secrets can be used as env vars", name, secret.Type)
+			case int32(revision) != secret.Revision:
+				problem.Message = fmt.Sprintf("secret %s is at revision %d, not %d", name, secret.Revision, revision)
+			default:
+				continue
+			}
+			problems = append(problems, problem)
+		}
+	}
+	return problems
+}
+
+// ExpandSecretReferences rewrites every NAME or NAME@latest secret reference in documents to
+// projects:<project id>/<NAME>@<revision>, using the current revision of the secret in the
+// function's project. secrets are keyed by project then name. A problem is returned for every
+// short reference to a secret that doesn't exist.
+func ExpandSecretReferences(documents []FunctionDocument, secrets map[string]map[string]openapi_chaos_client.SecretMetaResponse) []SecretProblem {
+	var problems []SecretProblem
+	for _, doc := range documents {
+		for i, env := range doc.Body.Secrets {
+			parts := shortSecretRef.FindStringSubmatch(env.Reference)
+			if parts == nil {
+				continue
+			}
+
+			secret, ok := secrets[doc.Body.ProjectId][parts[1]]
+			if !ok {
+				problems = append(problems, SecretProblem{
+					Source:    doc.Position(),
+					Function:  doc.Body.Name,
+					Env:       env.Name,
+					Reference: env.Reference,
+					Message:   fmt.Sprintf("secret %s doesn't exist in project %s", parts[1], doc.Body.ProjectId),
+				})
+				continue
+			}
+			doc.Body.Secrets[i].Reference = fmt.Sprintf("projects:%s/%s@%d", doc.Body.ProjectId, secret.Name, secret.Revision)
+		}
+	}
+	return problems
+}
+
+// hasShortSecretReferences reports whether any function in documents uses a NAME or NAME@latest reference
+func hasShortSecretReferences(documents []FunctionDocument) bool {
+	for _, doc := range documents {
+		for _, env := range doc.Body.Secrets {
+			if shortSecretRef.MatchString(env.Reference) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ResolveFunctionSecrets expands the short secret references in documents and, if check is set,
+// checks that every reference resolves to an environment secret at its current revision. All
+// problems are reported in one error.
+func ResolveFunctionSecrets(ctx context.Context, qc *client.QernalAPIClient, printer *utils.Printer, documents []FunctionDocument, check bool) error {
+	if !check && !hasShortSecretReferences(documents) {
+		return nil
+	}
+
+	secrets, err := ProjectSecrets(ctx, qc, printer, functionProjects(documents))
+	if err != nil {
+		return err
+	}
+
+	problems := ExpandSecretReferences(documents, secrets)
+	var checked []SecretProblem
+	if check {
+		checked = CheckFunctionSecrets(documents, secrets)
+		problems = append(problems, checked...)
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+
+	messages := make([]string, 0, len(problems))
+	for _, problem := range problems {
+		messages = append(messages, problem.Error())
+	}
+	message := fmt.Sprintf("found %d problems with secret references", len(problems))
+	if len(checked) > 0 {
+		message += ", use --skip-secret-check to submit anyway"
+	}
+	return utils.UsageError(message + "\n" + strings.Join(messages, "\n"))
+}
@@ -0,0 +1,84 @@
+package helpers
+
+import (
+	"testing"
+
+	openapi_chaos_client "github.com/qernal/openapi-chaos-go-client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	secretsProjectID = "b4b3c1d0-3b1b-4e45-9c55-8e2a6a1e3c6d"
+	otherProjectID   = "c5c4d2e1-3b1b-4e45-9c55-8e2a6a1e3c6d"
+)
+
+func secretsDocument(refs ...string) FunctionDocument {
+	body := openapi_chaos_client.FunctionBody{ProjectId: secretsProjectID, Name: "api"}
+	for _, ref := range refs {
+		body.Secrets = append(body.Secrets, openapi_chaos_client.FunctionEnv{Name: "ENV", Reference: ref})
+	}
+	return FunctionDocument{Source: "functions.yaml", Index: 1, Body: body}
+}
+
+func TestCheckFunctionSecrets(t *testing.T) {
+	secrets := map[string]map[string]openapi_chaos_client.SecretMetaResponse{
+		secretsProjectID: {
+			"DATABASE_URL": {Name: "DATABASE_URL", Type: openapi_chaos_client.SECRETMETATYPE_ENVIRONMENT, Revision: 2},
+			"REGISTRY":     {Name: "REGISTRY", Type: openapi_chaos_client.SECRETMETATYPE_REGISTRY, Revision: 1},
+		},
+	}
+
+	problems := CheckFunctionSecrets([]FunctionDocument{secretsDocument("projects:" + secretsProjectID + "/DATABASE_URL@2")}, secrets)
+	assert.Empty(t, problems)
+
+	problems = CheckFunctionSecrets([]FunctionDocument{secretsDocument(
+		"projects:"+secretsProjectID+"/MISSING@1",
+		"projects:"+secretsProjectID+"/REGISTRY@1",
+		"projects:"+secretsProjectID+"/DATABASE_URL@1",
+		"projects:"+otherProjectID+"/DATABASE_URL@2",
+		"projects:DATABASE_URL",
+		"DATABASE_URL",
+	)}, secrets)
+
+	var messages []string
+	for _, problem := range problems {
+		messages = append(messages, problem.Message)
+	}
+	assert.Equal(t, []string{
+		"secret MISSING doesn't exist in project " + secretsProjectID,
+		"secret REGISTRY is a registry secret, only environment secrets can be used as env vars",
+		"secret DATABASE_URL is at revision 2, not 1",
+		"secret DATABASE_URL is in project " + otherProjectID + ", not the function's project " + secretsProjectID,
+		`reference "projects:DATABASE_URL" isn't in the form projects:<project id>/<SECRET_NAME>@<revision>`,
+	}, messages)
+
+	require.NotEmpty(t, problems)
+	assert.Equal(t, "functions.yaml (document 1): function api, env ENV: secret MISSING doesn't exist in project "+secretsProjectID, problems[0].Error())
+}
+
+func TestExpandSecretReferences(t *testing.T) {
+	secrets := map[string]map[string]openapi_chaos_client.SecretMetaResponse{
+		secretsProjectID: {
+			"DATABASE_URL": {Name: "DATABASE_URL", Type: openapi_chaos_client.SECRETMETATYPE_ENVIRONMENT, Revision: 3},
+		},
+	}
+	full := "projects:" + otherProjectID + "/DATABASE_URL@1"
+	documents := []FunctionDocument{secretsDocument("DATABASE_URL", "DATABASE_URL@latest", "MISSING", full)}
+
+	problems := ExpandSecretReferences(documents, secrets)
+	require.Len(t, problems, 1)
+	assert.Equal(t, "secret MISSING doesn't exist in project "+secretsProjectID, problems[0].Message)
+
+	var references []string
+	for _, env := range documents[0].Body.Secrets {
+		references = append(references, env.Reference)
+	}
+	expanded := "projects:" + secretsProjectID + "/DATABASE_URL@3"
+	assert.Equal(t, []string{expanded, expanded, "MISSING", full}, references)
+
+	// the unresolved short reference is only reported once
+	problems = CheckFunctionSecrets(documents, secrets)
+	require.Len(t, problems, 1)
+	assert.Equal(t, full, problems[0].Reference)
+}
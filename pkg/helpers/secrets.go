@@ -2,7 +2,6 @@ package helpers
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"log/slog"
 	"os"
@@ -22,7 +21,7 @@ func PaginateSecrets(printer *utils.Printer, ctx context.Context, qc *client.Qer
 		if data, ok := resData.(map[string]interface{}); ok {
 			if innerData, ok := data["data"].(map[string]interface{}); ok {
 				if nameErr, ok := innerData["name"].(string); ok {
-					return nil, printer.RenderError("unable to list projects", errors.New(nameErr))
+					return nil, printer.RenderError("unable to list projects", client.NewResponseError(nameErr, err))
 				}
 			}
 		}
@@ -57,7 +56,7 @@ func PaginateSecrets(printer *utils.Printer, ctx context.Context, qc *client.Qer
 			if data, ok := resData.(map[string]interface{}); ok {
 				if innerData, ok := data["data"].(map[string]interface{}); ok {
 					if nameErr, ok := innerData["name"].(string); ok {
-						return nil, printer.RenderError("unable to list projects", errors.New(nameErr))
+						return nil, printer.RenderError("unable to list projects", client.NewResponseError(nameErr, err))
 					}
 				}
 			}
@@ -1 +1,483 @@
 package helpers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ValidationError is a problem found in a function definition, positions are 1-based
+type ValidationError struct {
+	File     string `json:"file"`
+	Document int    `json:"document"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	Message  string `json:"message"`
+}
+
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("%s:%d:%d: %s", e.File, e.Line, e.Column, e.Message)
+}
+
+// valueKind is the yaml type a field must have
+type valueKind int
+
+const (
+	kindString valueKind = iota
+	kindInt
+	kindBool
+	kindMap
+	kindList
+)
+
+func (k valueKind) String() string {
+	return [...]string{"a string", "an integer", "true or false", "a mapping", "a list"}[k]
+}
+
+// fieldSpec describes a field of a function definition
+type fieldSpec struct {
+	kind     valueKind
+	required bool
+	fields   map[string]fieldSpec // fields of a mapping
+	items    *fieldSpec           // items of a list
+	check    func(n *yaml.Node) string
+	// checkMap validates the fields of a mapping against each other, it is only called if the fields are valid
+	checkMap func(n *yaml.Node, fields map[string]*yaml.Node) []nodeError
+}
+
+type nodeError struct {
+	node    *yaml.Node
+	message string
+}
+
+var (
+	secretNamePattern = regexp.MustCompile(`^[A-Z_][A-Z0-9_]*$`)
+	secretRefPattern  = regexp.MustCompile(`^(projects:[a-z0-9-]{36}/[A-Z0-9_]+@[0-9]+|[A-Z0-9_]+(@latest)?)$`)
+	uuidPattern       = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+	// HTTPMethods are the methods an http route can accept
+	HTTPMethods = []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
+)
+
+var functionSpec = fieldSpec{
+	kind: kindMap,
+	fields: map[string]fieldSpec{
+		// project_id or the name of a project, either can be left to --project
+		"project_id":  {kind: kindString, check: checkUUID},
+		"project":     {kind: kindString, check: checkNotEmpty},
+		"version":     {kind: kindString, required: true, check: checkNotEmpty},
+		"name":        {kind: kindString, required: true, check: checkNotEmpty},
+		"description": {kind: kindString, required: true},
+		"image":       {kind: kindString, required: true, check: checkNotEmpty},
+		"type":        {kind: kindString, required: true, check: checkOneOf("http", "worker")},
+		"size": {kind: kindMap, required: true, fields: map[string]fieldSpec{
+			"cpu":    {kind: kindInt, required: true, check: checkSizeUnit},
+			"memory": {kind: kindInt, required: true, check: checkSizeUnit},
+		}},
+		"port": {kind: kindInt, required: true, check: checkRange(1, 65535)},
+		"routes": {kind: kindList, items: &fieldSpec{kind: kindMap, fields: map[string]fieldSpec{
+			"path":    {kind: kindString, required: true, check: checkRoutePath},
+			"methods": {kind: kindList, required: true, items: &fieldSpec{kind: kindString, check: checkOneOf(HTTPMethods...)}},
+			"weight":  {kind: kindInt, required: true, check: checkRange(0, 100)},
+		}}},
+		"scaling": {kind: kindMap, required: true, checkMap: checkScaling, fields: map[string]fieldSpec{
+			"type": {kind: kindString, required: true, check: checkOneOf("cpu", "memory")},
+			"low":  {kind: kindInt, required: true, check: checkRange(0, 100)},
+			"high": {kind: kindInt, required: true, check: checkRange(0, 100)},
+		}},
+		"deployments": {kind: kindList, required: true, items: &fieldSpec{kind: kindMap, fields: map[string]fieldSpec{
+			"location": {kind: kindMap, required: true, fields: map[string]fieldSpec{
+				"provider_id": {kind: kindString, required: true, check: checkUUID},
+				"continent":   {kind: kindString},
+				"country":     {kind: kindString},
+				"city":        {kind: kindString},
+			}},
+			"replicas": {kind: kindMap, required: true, checkMap: checkReplicas, fields: map[string]fieldSpec{
+				"min": {kind: kindInt, required: true, check: checkRange(0, 1<<31-1)},
+				"max": {kind: kindInt, required: true, check: checkRange(1, 1<<31-1)},
+				"affinity": {kind: kindMap, required: true, fields: map[string]fieldSpec{
+					"cluster": {kind: kindBool, required: true},
+					"cloud":   {kind: kindBool, required: true},
+				}},
+			}},
+		}}},
+		"secrets": {kind: kindList, required: true, items: &fieldSpec{kind: kindMap, fields: map[string]fieldSpec{
+			"name":      {kind: kindString, required: true, check: checkPattern(secretNamePattern, "an upper case name, e.g. DATABASE_URL")},
+			"reference": {kind: kindString, required: true, check: checkPattern(secretRefPattern, "SECRET_NAME, SECRET_NAME@latest or projects:<project id>/<SECRET_NAME>@<revision>")},
+		}}},
+		"compliance": {kind: kindList, required: true, items: &fieldSpec{kind: kindString, check: checkOneOf("soc2", "ipv6")}},
+	},
+	checkMap: checkFunction,
+}
+
+// ValidateFunctionDocuments checks every function definition of a yaml stream against the function
+// schema without contacting the API, source is used in error positions
+func ValidateFunctionDocuments(source string, content []byte) []ValidationError {
+	return ValidateFunctionSources([]FunctionSource{{Name: source, Content: content}}, nil)
+}
+
+// ValidateFunctionSources checks the function definitions of every source after interpolating vars,
+// see LoadFunctionDocuments. Overlays only need to set the fields they change, so missing fields
+// aren't reported for them.
+func ValidateFunctionSources(sources []FunctionSource, vars Variables) []ValidationError {
+	var problems []ValidationError
+	// definedIn holds the functions defined so far, later definitions of them are overlays
+	definedIn := map[string]bool{}
+	// definedBy is the path and position each function was last defined or overlaid by
+	definedBy := map[string]definition{}
+
+	for _, source := range sources {
+		decoder := yaml.NewDecoder(bytes.NewReader(source.Content))
+		for index := 1; ; index++ {
+			var doc yaml.Node
+			err := decoder.Decode(&doc)
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				line, message := yamlErrorPosition(err)
+				problems = append(problems, ValidationError{File: source.Name, Document: index, Line: line, Column: 1, Message: message})
+				// the decoder can't recover from a syntax error
+				break
+			}
+			for _, variable := range Interpolate(&doc, vars) {
+				problems = append(problems, ValidationError{
+					File:     source.Name,
+					Document: index,
+					Line:     variable.Line,
+					Column:   variable.Column,
+					Message:  fmt.Sprintf("variable %s is not set", variable.Name),
+				})
+			}
+			if len(doc.Content) == 0 || isNull(doc.Content[0]) {
+				continue
+			}
+
+			root := doc.Content[0]
+			partial := false
+			if name := functionName(root); name != nil {
+				position := fmt.Sprintf("%s:%d:%d", source.Name, name.Line, name.Column)
+				if previous, ok := definedBy[name.Value]; ok && previous.arg == source.Arg {
+					problems = append(problems, ValidationError{
+						File:     source.Name,
+						Document: index,
+						Line:     name.Line,
+						Column:   name.Column,
+						Message:  fmt.Sprintf("function %s is defined more than once, in %s and %s", name.Value, previous.position, position),
+					})
+				} else {
+					definedBy[name.Value] = definition{arg: source.Arg, position: position}
+				}
+				partial = definedIn[name.Value]
+				definedIn[name.Value] = true
+			}
+
+			for _, problem := range validateNode(root, functionSpec, "", partial) {
+				problems = append(problems, ValidationError{
+					File:     source.Name,
+					Document: index,
+					Line:     problem.node.Line,
+					Column:   problem.node.Column,
+					Message:  problem.message,
+				})
+			}
+		}
+	}
+
+	return problems
+}
+
+// definition is where a function was defined
+type definition struct {
+	arg      int
+	position string
+}
+
+// functionName returns the name node of a function definition, if it has one
+func functionName(n *yaml.Node) *yaml.Node {
+	if n.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == "name" && n.Content[i+1].Kind == yaml.ScalarNode && n.Content[i+1].Value != "" {
+			return n.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// validateNode checks n against spec, path is the dotted path of n used in messages. A partial node
+// is an overlay, missing fields and fields that depend on each other aren't checked.
+func validateNode(n *yaml.Node, spec fieldSpec, path string, partial bool) []nodeError {
+	if n.Kind == yaml.AliasNode {
+		n = n.Alias
+	}
+
+	if message := checkKind(n, spec.kind); message != "" {
+		return []nodeError{{n, fieldMessage(path, message)}}
+	}
+	if isNull(n) {
+		return nil
+	}
+
+	var problems []nodeError
+	switch spec.kind {
+	case kindMap:
+		fields := map[string]*yaml.Node{}
+		// fields suggested for a typo aren't reported as missing as well
+		suggested := map[string]bool{}
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key, value := n.Content[i], n.Content[i+1]
+			fieldSpec, ok := spec.fields[key.Value]
+			if !ok {
+				suggestion := closestField(key.Value, spec.fields)
+				suggested[suggestion] = true
+				problems = append(problems, nodeError{key, unknownFieldMessage(joinPath(path, key.Value), suggestion)})
+				continue
+			}
+			if _, dup := fields[key.Value]; dup {
+				problems = append(problems, nodeError{key, fmt.Sprintf("%s is set more than once", joinPath(path, key.Value))})
+				continue
+			}
+			fields[key.Value] = value
+			problems = append(problems, validateNode(value, fieldSpec, joinPath(path, key.Value), partial)...)
+		}
+
+		for _, name := range sortedKeys(spec.fields) {
+			if _, ok := fields[name]; !ok && spec.fields[name].required && !suggested[name] && !partial {
+				problems = append(problems, nodeError{n, fmt.Sprintf("missing required field %s", joinPath(path, name))})
+			}
+		}
+
+		if len(problems) == 0 && spec.checkMap != nil && !partial {
+			problems = append(problems, spec.checkMap(n, fields)...)
+		}
+	case kindList:
+		for i, item := range n.Content {
+			// lists replace the list of the base definition, so their items are complete
+			problems = append(problems, validateNode(item, *spec.items, fmt.Sprintf("%s[%d]", path, i), false)...)
+		}
+	default:
+		if spec.check != nil {
+			if message := spec.check(n); message != "" {
+				problems = append(problems, nodeError{n, fieldMessage(path, message)})
+			}
+		}
+	}
+
+	return problems
+}
+
+// checkKind returns a message if n isn't of the expected kind, null is accepted for optional values
+func checkKind(n *yaml.Node, kind valueKind) string {
+	if isNull(n) {
+		if kind == kindMap {
+			return "must be " + kind.String()
+		}
+		return ""
+	}
+
+	ok := false
+	switch kind {
+	case kindMap:
+		ok = n.Kind == yaml.MappingNode
+	case kindList:
+		ok = n.Kind == yaml.SequenceNode
+	case kindString:
+		ok = n.Kind == yaml.ScalarNode && n.ShortTag() == "!!str"
+	case kindInt:
+		ok = n.Kind == yaml.ScalarNode && n.ShortTag() == "!!int"
+	case kindBool:
+		ok = n.Kind == yaml.ScalarNode && n.ShortTag() == "!!bool"
+	}
+	if ok {
+		return ""
+	}
+
+	if n.Kind == yaml.ScalarNode {
+		if kind == kindString {
+			return fmt.Sprintf("must be %s, quote %q to use it as one", kind, n.Value)
+		}
+		return fmt.Sprintf("must be %s, got %q", kind, n.Value)
+	}
+	return "must be " + kind.String()
+}
+
+func isNull(n *yaml.Node) bool {
+	return n.Kind == yaml.ScalarNode && n.ShortTag() == "!!null"
+}
+
+func checkNotEmpty(n *yaml.Node) string {
+	if strings.TrimSpace(n.Value) == "" {
+		return "must not be empty"
+	}
+	return ""
+}
+
+func checkUUID(n *yaml.Node) string {
+	if !uuidPattern.MatchString(n.Value) {
+		return fmt.Sprintf("must be a UUID, got %q", n.Value)
+	}
+	return ""
+}
+
+func checkOneOf(values ...string) func(n *yaml.Node) string {
+	return func(n *yaml.Node) string {
+		for _, v := range values {
+			if n.Value == v {
+				return ""
+			}
+		}
+		return fmt.Sprintf("must be one of %s, got %q", strings.Join(values, ", "), n.Value)
+	}
+}
+
+func checkRange(low int, high int) func(n *yaml.Node) string {
+	return func(n *yaml.Node) string {
+		v, err := strconv.Atoi(n.Value)
+		if err != nil || v < low || v > high {
+			return fmt.Sprintf("must be between %d and %d, got %s", low, high, n.Value)
+		}
+		return ""
+	}
+}
+
+func checkPattern(pattern *regexp.Regexp, format string) func(n *yaml.Node) string {
+	return func(n *yaml.Node) string {
+		if !pattern.MatchString(n.Value) {
+			return fmt.Sprintf("must be %s, got %q", format, n.Value)
+		}
+		return ""
+	}
+}
+
+// checkSizeUnit checks cpu and memory are in 128 unit steps
+func checkSizeUnit(n *yaml.Node) string {
+	v, err := strconv.Atoi(n.Value)
+	if err != nil || v <= 0 || v%128 != 0 {
+		return fmt.Sprintf("must be a positive multiple of 128, got %s", n.Value)
+	}
+	return ""
+}
+
+// checkRoutePath checks a route is an absolute path, routes can be regular expressions
+func checkRoutePath(n *yaml.Node) string {
+	if !strings.HasPrefix(n.Value, "/") {
+		return fmt.Sprintf("must start with /, got %q", n.Value)
+	}
+	if _, err := regexp.Compile(n.Value); err != nil {
+		return fmt.Sprintf("is not a valid path expression, %s", err.Error())
+	}
+	return ""
+}
+
+func checkScaling(n *yaml.Node, fields map[string]*yaml.Node) []nodeError {
+	low, _ := strconv.Atoi(fields["low"].Value)
+	high, _ := strconv.Atoi(fields["high"].Value)
+	if low >= high {
+		return []nodeError{{fields["low"], fmt.Sprintf("scaling low (%d) must be less than high (%d)", low, high)}}
+	}
+	return nil
+}
+
+func checkReplicas(n *yaml.Node, fields map[string]*yaml.Node) []nodeError {
+	minReplicas, _ := strconv.Atoi(fields["min"].Value)
+	maxReplicas, _ := strconv.Atoi(fields["max"].Value)
+	if minReplicas > maxReplicas {
+		return []nodeError{{fields["min"], fmt.Sprintf("replicas min (%d) must not be greater than max (%d)", minReplicas, maxReplicas)}}
+	}
+	return nil
+}
+
+// checkFunction checks fields that depend on the function type and that only one project is set
+func checkFunction(n *yaml.Node, fields map[string]*yaml.Node) []nodeError {
+	var problems []nodeError
+	if project, ok := fields["project"]; ok && fields["project_id"] != nil {
+		problems = append(problems, nodeError{project, "set either project or project_id, not both"})
+	}
+	routes, ok := fields["routes"]
+	if ok && len(routes.Content) > 0 && fields["type"].Value != "http" {
+		problems = append(problems, nodeError{routes, "routes are only applicable to http functions"})
+	}
+	return problems
+}
+
+func fieldMessage(path string, message string) string {
+	if path == "" {
+		return "function definition " + message
+	}
+	return path + " " + message
+}
+
+// closestField returns the known field name is most likely a typo of, if any
+func closestField(name string, fields map[string]fieldSpec) string {
+	best, bestDistance := "", 3
+	for _, field := range sortedKeys(fields) {
+		if d := editDistance(name, field); d < bestDistance {
+			best, bestDistance = field, d
+		}
+	}
+	return best
+}
+
+func unknownFieldMessage(path string, suggestion string) string {
+	if suggestion != "" {
+		return fmt.Sprintf("unknown field %s, did you mean %s?", path, suggestion)
+	}
+	return fmt.Sprintf("unknown field %s", path)
+}
+
+func joinPath(path string, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
+
+func sortedKeys(fields map[string]fieldSpec) []string {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// editDistance is the Levenshtein distance between a and b
+func editDistance(a string, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+	return previous[len(b)]
+}
+
+var yamlLinePattern = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+
+// yamlErrorPosition extracts the line from a yaml syntax error
+func yamlErrorPosition(err error) (int, string) {
+	if m := yamlLinePattern.FindStringSubmatch(err.Error()); m != nil {
+		line, _ := strconv.Atoi(m[1])
+		return line, m[2]
+	}
+	return 1, strings.TrimPrefix(err.Error(), "yaml: ")
+}
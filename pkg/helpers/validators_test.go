@@ -0,0 +1,128 @@
+package helpers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const validFunction = `project_id: b4b3c1d0-3b1b-4e45-9c55-8e2a6a1e3c6d
+version: "1.0.0"
+name: api
+description: public api
+image: nginx:latest
+type: http
+size:
+  cpu: 256
+  memory: 256
+port: 80
+routes:
+  - path: /api
+    methods: [GET, POST]
+    weight: 100
+scaling:
+  type: cpu
+  low: 10
+  high: 80
+deployments:
+  - location:
+      provider_id: 5c7c7a39-3b1b-4e45-9c55-8e2a6a1e3c6d
+      continent: Europe
+    replicas:
+      min: 1
+      max: 3
+      affinity:
+        cluster: false
+        cloud: false
+secrets:
+  - name: DATABASE_URL
+    reference: projects:b4b3c1d0-3b1b-4e45-9c55-8e2a6a1e3c6d/DATABASE_URL@1
+compliance: []
+`
+
+func TestValidateFunctionDocuments(t *testing.T) {
+	assert.Empty(t, ValidateFunctionDocuments("functions.yaml", []byte(validFunction+"---\n"+namedFunction("worker"))))
+	assert.Empty(t, ValidateFunctionDocuments("functions.yaml", []byte(strings.Replace(validFunction, "memory: 256", "memory: 1024", 1))), "cpu and memory are sized independently")
+	assert.Empty(t, ValidateFunctionDocuments("functions.yaml", []byte(strings.ReplaceAll(validFunction, "DATABASE_URL", "S3_BUCKET"))), "secret names can contain digits")
+}
+
+func TestValidateFunctionSourcesOverlays(t *testing.T) {
+	overlay := "name: api\nimage: ${IMAGE}\nsize:\n  cpu: 512\n"
+	sources := []FunctionSource{
+		{Name: "base.yaml", Content: []byte(validFunction)},
+		{Name: "prod.yaml", Content: []byte(overlay + "---\n" + overlay), Arg: 1},
+	}
+
+	problems := ValidateFunctionSources(sources, Variables{"IMAGE": "nginx:1.27"})
+	require.Len(t, problems, 1, "an overlay only needs the fields it changes")
+	assert.Equal(t, "prod.yaml:6:7: function api is defined more than once, in prod.yaml:1:7 and prod.yaml:6:7", problems[0].Error())
+
+	problems = ValidateFunctionSources(sources[:1], nil)
+	assert.Empty(t, problems)
+
+	sources[1].Arg = 0
+	problems = ValidateFunctionSources(sources[:2], Variables{"IMAGE": "nginx:1.27"})
+	assert.Contains(t, problems, ValidationError{File: "prod.yaml", Document: 1, Line: 1, Column: 7, Message: "function api is defined more than once, in base.yaml:3:7 and prod.yaml:1:7"}, "files of the same path can't overlay each other")
+
+	problems = ValidateFunctionSources([]FunctionSource{{Name: "prod.yaml", Content: []byte(overlay)}}, nil)
+	assert.Contains(t, problems, ValidationError{File: "prod.yaml", Document: 1, Line: 2, Column: 8, Message: "variable IMAGE is not set"})
+	assert.Greater(t, len(problems), 1, "an overlay without a base is a complete definition")
+}
+
+func TestValidateFunctionDocumentsErrors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		replace  [2]string
+		expected string
+	}{
+		{name: "unknown field", replace: [2]string{"image: nginx", "imgae: nginx"}, expected: "functions.yaml:5:1: unknown field imgae, did you mean image?"},
+		{name: "missing field", replace: [2]string{"port: 80\n", ""}, expected: "functions.yaml:1:1: missing required field port"},
+		{name: "enum", replace: [2]string{"type: http", "type: web"}, expected: `functions.yaml:6:7: type must be one of http, worker, got "web"`},
+		{name: "port", replace: [2]string{"port: 80", "port: 70000"}, expected: "functions.yaml:10:7: port must be between 1 and 65535, got 70000"},
+		{name: "string type", replace: [2]string{`version: "1.0.0"`, "version: 1.0"}, expected: `functions.yaml:2:10: version must be a string, quote "1.0" to use it as one`},
+		{name: "route", replace: [2]string{"path: /api", "path: api"}, expected: `functions.yaml:12:11: routes[0].path must start with /, got "api"`},
+		{name: "method", replace: [2]string{"[GET, POST]", "[GET, FETCH]"}, expected: `functions.yaml:13:20: routes[0].methods[1] must be one of GET, HEAD, POST, PUT, PATCH, DELETE, OPTIONS, got "FETCH"`},
+		{name: "replicas", replace: [2]string{"min: 1", "min: 5"}, expected: "functions.yaml:24:12: replicas min (5) must not be greater than max (3)"},
+		{name: "secret reference", replace: [2]string{"reference: projects:b4b3c1d0-3b1b-4e45-9c55-8e2a6a1e3c6d/DATABASE_URL@1", "reference: DATABASE_URL@2"}, expected: `functions.yaml:31:16: secrets[0].reference must be SECRET_NAME, SECRET_NAME@latest or projects:<project id>/<SECRET_NAME>@<revision>, got "DATABASE_URL@2"`},
+		{name: "project", replace: [2]string{"name: api\n", "name: api\nproject: prod\n"}, expected: "functions.yaml:4:10: set either project or project_id, not both"},
+		{name: "secret name", replace: [2]string{"name: DATABASE_URL", "name: 3_BUCKET"}, expected: `functions.yaml:30:11: secrets[0].name must be an upper case name, e.g. DATABASE_URL, got "3_BUCKET"`},
+		{name: "size", replace: [2]string{"memory: 256", "memory: 500"}, expected: "functions.yaml:9:11: size.memory must be a positive multiple of 128, got 500"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := strings.Replace(validFunction, tc.replace[0], tc.replace[1], 1)
+			problems := ValidateFunctionDocuments("functions.yaml", []byte(doc))
+			require.Len(t, problems, 1)
+			assert.Equal(t, tc.expected, problems[0].Error())
+		})
+	}
+}
+
+func TestValidateFunctionDocumentsShortSecrets(t *testing.T) {
+	for _, reference := range []string{"DATABASE_URL", "DATABASE_URL@latest"} {
+		doc := strings.Replace(validFunction, "projects:b4b3c1d0-3b1b-4e45-9c55-8e2a6a1e3c6d/DATABASE_URL@1", reference, 1)
+		assert.Empty(t, ValidateFunctionDocuments("functions.yaml", []byte(doc)), reference)
+	}
+}
+
+func TestValidateFunctionDocumentsProjectName(t *testing.T) {
+	doc := strings.Replace(validFunction, "project_id: b4b3c1d0-3b1b-4e45-9c55-8e2a6a1e3c6d", "project: staging", 1)
+	assert.Empty(t, ValidateFunctionDocuments("functions.yaml", []byte(doc)))
+}
+
+func TestValidateFunctionDocumentsIndex(t *testing.T) {
+	doc := validFunction + "---\n" + strings.Replace(namedFunction("worker"), "type: http", "type: web", 1)
+	problems := ValidateFunctionDocuments("functions.yaml", []byte(doc))
+	require.Len(t, problems, 1)
+	assert.Equal(t, 2, problems[0].Document)
+	assert.Equal(t, 39, problems[0].Line)
+}
+
+func TestValidateFunctionDocumentsSyntax(t *testing.T) {
+	problems := ValidateFunctionDocuments("functions.yaml", []byte("name: api\n  image: [\n"))
+	require.Len(t, problems, 1)
+	assert.Equal(t, 2, problems[0].Line)
+}
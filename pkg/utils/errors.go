@@ -0,0 +1,202 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/qernal/cli-qernal/charm"
+	"github.com/qernal/cli-qernal/pkg/common"
+	openapi_chaos_client "github.com/qernal/openapi-chaos-go-client"
+)
+
+// Exit codes returned by the CLI, scripts can branch on these to tell failures apart
+const (
+	ExitOK          = 0
+	ExitError       = 1   // unclassified failure
+	ExitUsage       = 2   // invalid flags, arguments or input
+	ExitAuth        = 3   // missing, invalid or rejected credentials
+	ExitNotFound    = 4   // the requested resource does not exist
+	ExitConflict    = 5   // the resource already exists or was modified concurrently
+	ExitAPI         = 6   // the API returned a server side error
+	ExitTimeout     = 7   // a request or wait timed out
+	ExitPartial     = 8   // some, but not all, operations in a batch failed
+	ExitDrift       = 9   // live resources differ from their local definition
+	ExitInterrupted = 130 // cancelled by the user (ctrl+c)
+)
+
+var exitCodeTypes = map[int]string{
+	ExitError:       "error",
+	ExitUsage:       "usage",
+	ExitAuth:        "auth",
+	ExitNotFound:    "not_found",
+	ExitConflict:    "conflict",
+	ExitAPI:         "api",
+	ExitTimeout:     "timeout",
+	ExitPartial:     "partial_failure",
+	ExitDrift:       "drift",
+	ExitInterrupted: "interrupted",
+}
+
+// CLIError is an error carrying the exit code the CLI should terminate with
+type CLIError struct {
+	Code    int
+	Message string
+	Err     error
+}
+
+func (e *CLIError) Error() string {
+	if e.Err == nil {
+		return e.Message
+	}
+	if e.Message == "" {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%s: %s", e.Message, e.Err.Error())
+}
+
+func (e *CLIError) Unwrap() error {
+	return e.Err
+}
+
+// NewError returns an error that makes the CLI exit with the given code
+func NewError(code int, message string, err ...error) error {
+	cliErr := &CLIError{Code: code, Message: message}
+	if len(err) > 0 {
+		cliErr.Err = err[0]
+	}
+	return cliErr
+}
+
+// UsageError returns an error for invalid flags or arguments
+func UsageError(message string, err ...error) error {
+	return NewError(ExitUsage, message, err...)
+}
+
+// cobra doesn't return typed errors for these, match on the message instead
+var usageErrorPrefixes = []string{
+	"required flag(s)",
+	"unknown command",
+	"if any flags in the group",
+	// argument count errors of cobra.ExactArgs, MinimumNArgs, MaximumNArgs and RangeArgs
+	"accepts ",
+	"requires at least ",
+	// cobra.OnlyValidArgs
+	"invalid argument ",
+}
+
+// CommandError returns err as a usage error if cobra returned it for an invalid command, flag or
+// argument, other errors are returned unchanged
+func CommandError(err error) error {
+	if err == nil || ExitCode(err) != ExitError {
+		return err
+	}
+	for _, prefix := range usageErrorPrefixes {
+		if strings.HasPrefix(err.Error(), prefix) {
+			return UsageError("", err)
+		}
+	}
+	return err
+}
+
+// BatchError summarises a batch of operations, it returns nil when nothing failed and a
+// partial failure when at least one operation succeeded
+func BatchError(action string, failed, total int) error {
+	if failed == 0 {
+		return nil
+	}
+	message := fmt.Sprintf("%s failed for %d of %d resources", action, failed, total)
+	if failed < total {
+		return NewError(ExitPartial, message)
+	}
+	return NewError(ExitError, message)
+}
+
+// ExitCode maps an error returned by a command to one of the documented exit codes
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+
+	var cliErr *CLIError
+	if errors.As(err, &cliErr) {
+		return cliErr.Code
+	}
+
+	if errors.Is(err, charm.ErrInterrupted) || errors.Is(err, context.Canceled) {
+		return ExitInterrupted
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ExitTimeout
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return ExitTimeout
+	}
+
+	var apiErr *openapi_chaos_client.GenericOpenAPIError
+	if errors.As(err, &apiErr) {
+		return exitCodeForStatus(apiErr.Error())
+	}
+
+	return ExitError
+}
+
+// ExitCodeType returns the machine readable name of an exit code
+func ExitCodeType(code int) string {
+	if t, ok := exitCodeTypes[code]; ok {
+		return t
+	}
+	return exitCodeTypes[ExitError]
+}
+
+// exitCodeForStatus classifies an API error, the client sets the error string to the HTTP status (e.g. "404 Not Found")
+func exitCodeForStatus(status string) int {
+	code, err := strconv.Atoi(strings.SplitN(status, " ", 2)[0])
+	if err != nil {
+		return ExitAPI
+	}
+
+	switch {
+	case code == 400 || code == 422:
+		return ExitUsage
+	case code == 401 || code == 403:
+		return ExitAuth
+	case code == 404:
+		return ExitNotFound
+	case code == 409 || code == 412:
+		return ExitConflict
+	case code == 408 || code == 504:
+		return ExitTimeout
+	default:
+		return ExitAPI
+	}
+}
+
+// PrintError writes err to stderr, as a json object when json output is enabled
+func (p *Printer) PrintError(err error) {
+	code := ExitCode(err)
+	message := strings.TrimSpace(ansi.Strip(err.Error()))
+
+	if common.OutputFormat == "json" {
+		errObj := map[string]interface{}{
+			"error": map[string]interface{}{
+				"code":    code,
+				"type":    ExitCodeType(code),
+				"message": message,
+			},
+		}
+		out, _ := json.MarshalIndent(errObj, "", "  ")
+		fmt.Fprintln(p.errOut, string(out))
+		return
+	}
+
+	fmt.Fprintln(p.errOut, charm.ErrorStyle.Render("Error:"), err.Error())
+}
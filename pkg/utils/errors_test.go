@@ -0,0 +1,101 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/qernal/cli-qernal/charm"
+	"github.com/qernal/cli-qernal/pkg/common"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExitCode(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{name: "nil", err: nil, expected: ExitOK},
+		{name: "plain error", err: errors.New("boom"), expected: ExitError},
+		{name: "usage", err: UsageError("bad flag"), expected: ExitUsage},
+		{name: "wrapped cli error", err: charm.RenderError("unable to find function", NewError(ExitNotFound, "missing")), expected: ExitNotFound},
+		{name: "interrupted", err: fmt.Errorf("prompt: %w", charm.ErrInterrupted), expected: ExitInterrupted},
+		{name: "deadline", err: fmt.Errorf("wait: %w", context.DeadlineExceeded), expected: ExitTimeout},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, ExitCode(tc.err))
+		})
+	}
+}
+
+func TestCommandError(t *testing.T) {
+	cmd := &cobra.Command{Use: "get", ValidArgs: []string{"api"}}
+	testCases := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{name: "exact args", err: cobra.ExactArgs(1)(cmd, nil), expected: ExitUsage},
+		{name: "minimum args", err: cobra.MinimumNArgs(2)(cmd, []string{"a"}), expected: ExitUsage},
+		{name: "maximum args", err: cobra.MaximumNArgs(1)(cmd, []string{"a", "b"}), expected: ExitUsage},
+		{name: "range args", err: cobra.RangeArgs(1, 2)(cmd, nil), expected: ExitUsage},
+		{name: "no args", err: cobra.NoArgs(cmd, []string{"a"}), expected: ExitUsage},
+		{name: "valid args", err: cobra.OnlyValidArgs(cmd, []string{"web"}), expected: ExitUsage},
+		{name: "required flag", err: errors.New(`required flag(s) "function" not set`), expected: ExitUsage},
+		{name: "other error", err: errors.New("accepted the request, but it failed"), expected: ExitError},
+		{name: "classified error", err: NewError(ExitNotFound, "accepts 1 arg(s)"), expected: ExitNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Error(t, tc.err)
+			assert.Equal(t, tc.expected, ExitCode(CommandError(tc.err)))
+		})
+	}
+	assert.NoError(t, CommandError(nil))
+}
+
+func TestExitCodeForStatus(t *testing.T) {
+	assert.Equal(t, ExitAuth, exitCodeForStatus("401 Unauthorized"))
+	assert.Equal(t, ExitNotFound, exitCodeForStatus("404 Not Found"))
+	assert.Equal(t, ExitConflict, exitCodeForStatus("409 Conflict"))
+	assert.Equal(t, ExitUsage, exitCodeForStatus("400 Bad Request"))
+	assert.Equal(t, ExitAPI, exitCodeForStatus("500 Internal Server Error"))
+	assert.Equal(t, ExitAPI, exitCodeForStatus("connection refused"))
+}
+
+func TestBatchError(t *testing.T) {
+	assert.NoError(t, BatchError("delete", 0, 3))
+	assert.Equal(t, ExitPartial, ExitCode(BatchError("delete", 1, 3)))
+	assert.Equal(t, ExitError, ExitCode(BatchError("delete", 3, 3)))
+}
+
+func TestPrintErrorJSON(t *testing.T) {
+	common.OutputFormat = "json"
+	t.Cleanup(func() { common.OutputFormat = "text" })
+
+	var buf bytes.Buffer
+	printer := NewPrinter()
+	printer.SetErr(&buf)
+	printer.PrintError(charm.RenderError("unable to find function", NewError(ExitNotFound, "no function with name api")))
+
+	var errObj struct {
+		Error struct {
+			Code    int    `json:"code"`
+			Type    string `json:"type"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &errObj))
+	assert.Equal(t, ExitNotFound, errObj.Error.Code)
+	assert.Equal(t, "not_found", errObj.Error.Type)
+	assert.Equal(t, "unable to find function: no function with name api", errObj.Error.Message)
+}
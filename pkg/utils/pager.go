@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/charmbracelet/x/term"
+	"github.com/qernal/cli-qernal/pkg/common"
+)
+
+// defaultPager is used when neither QERNAL_PAGER nor PAGER is set
+const defaultPager = "less"
+
+// PrintPaged prints data like PrintResource, piping it through a pager when stdout is a terminal and
+// the output is taller than the screen. The pager is taken from $QERNAL_PAGER, then $PAGER, and
+// defaults to less. Setting either variable to an empty string or cat disables paging.
+func (p *Printer) PrintPaged(data string) {
+	file, ok := p.resourceOut.(*os.File)
+	if !ok || common.NoPager || common.Watch || !term.IsTerminal(file.Fd()) {
+		p.PrintResource(data)
+		return
+	}
+
+	_, height, err := term.GetSize(file.Fd())
+	if err != nil || !exceedsHeight(data, height) {
+		p.PrintResource(data)
+		return
+	}
+
+	args := pagerCommand()
+	if len(args) == 0 {
+		p.PrintResource(data)
+		return
+	}
+
+	pager := exec.Command(args[0], args[1:]...)
+	pager.Stdin = strings.NewReader(data + "\n")
+	pager.Stdout = file
+	pager.Stderr = p.errOut
+	pager.Env = os.Environ()
+	if _, set := os.LookupEnv("LESS"); !set {
+		// quit if the output fits on one screen, keep colours and don't clear the screen on exit
+		pager.Env = append(pager.Env, "LESS=FRX")
+	}
+
+	if err := pager.Run(); err != nil {
+		p.Logger.Debug("unable to run pager, printing output directly", "pager", args[0], "error", err.Error())
+		if pager.Process == nil {
+			p.PrintResource(data)
+		}
+	}
+}
+
+// pagerCommand returns the pager to run split into its arguments, or nothing if paging is disabled
+func pagerCommand() []string {
+	pager, ok := os.LookupEnv("QERNAL_PAGER")
+	if !ok {
+		pager, ok = os.LookupEnv("PAGER")
+	}
+	if !ok {
+		pager = defaultPager
+	}
+
+	args := strings.Fields(pager)
+	if len(args) == 0 || args[0] == "cat" {
+		return nil
+	}
+	return args
+}
+
+// exceedsHeight reports whether data has more lines than fit on a screen of the given height
+func exceedsHeight(data string, height int) bool {
+	if height <= 0 {
+		return false
+	}
+	return strings.Count(strings.TrimRight(data, "\n"), "\n")+1 > height
+}
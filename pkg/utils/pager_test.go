@@ -0,0 +1,25 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPagerCommand(t *testing.T) {
+	t.Setenv("QERNAL_PAGER", "less -S")
+	t.Setenv("PAGER", "more")
+	assert.Equal(t, []string{"less", "-S"}, pagerCommand())
+
+	t.Setenv("QERNAL_PAGER", "")
+	assert.Nil(t, pagerCommand(), "an empty QERNAL_PAGER disables paging")
+
+	t.Setenv("QERNAL_PAGER", "cat")
+	assert.Nil(t, pagerCommand())
+}
+
+func TestExceedsHeight(t *testing.T) {
+	assert.False(t, exceedsHeight("a\nb\n", 2))
+	assert.True(t, exceedsHeight("a\nb\nc", 2))
+	assert.False(t, exceedsHeight("a\nb\nc", 0), "unknown terminal height")
+}
@@ -3,16 +3,17 @@ package utils
 import (
 	"bytes"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	math_rand "math/rand"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/qernal/cli-qernal/charm"
 	"github.com/qernal/cli-qernal/pkg/common"
+	"github.com/spf13/cobra"
 )
 
 func PrettyPrintJSON(data interface{}) (string, error) {
@@ -58,6 +59,7 @@ func FormatOutput(data interface{}, outputType string) string {
 
 type Printer struct {
 	resourceOut io.Writer
+	errOut      io.Writer
 	//mostly for debug level logs, for rendering errors, see charm package
 	Logger *slog.Logger
 }
@@ -75,12 +77,13 @@ func NewPrinter() *Printer {
 	}
 
 	// Initialize the logger with the specified log level
-	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
 		Level: lvl,
 	}))
 
 	return &Printer{
 		resourceOut: os.Stdout,
+		errOut:      os.Stderr,
 		Logger:      logger,
 	}
 }
@@ -90,6 +93,11 @@ func (p *Printer) SetOut(out io.Writer) {
 	p.resourceOut = out
 }
 
+// SetErr sets the output for errors and warnings.
+func (p *Printer) SetErr(out io.Writer) {
+	p.errOut = out
+}
+
 // FormatOutput formats data based on the output type
 func (p *Printer) FormatOutput(data interface{}, outputType string) string {
 
@@ -119,23 +127,16 @@ func (p *Printer) FormatOutput(data interface{}, outputType string) string {
 	}
 }
 
-// RenderError handles API error responses, formatting them as JSON when json output is enabled.
-// For general error rendering with colored output, use `charm.RenderError` instead.
+// RenderError formats an error returned by a command, the underlying error is wrapped so the
+// exit code can be derived from it. Errors are written to stderr by the root command, as a json
+// object when json output is enabled.
 // Example:
 //
 //	if err != nil {
-//	    return printer.RenderError("failed to create resource", err) // Will output {"error": "reason"} for json
+//	    return printer.RenderError("failed to create resource", err) // {"error": {"code": 5, "type": "conflict", ...}} for json
 //	}
 func (p *Printer) RenderError(message string, err ...error) error {
-	if len(err) > 0 && err[0] != nil {
-		if common.OutputFormat == "json" {
-			p.PrintResource(p.FormatOutput(err[0], common.OutputFormat))
-			os.Exit(1)
-			return nil // Empty error to avoid duplicate output
-		}
-		return fmt.Errorf("%s", charm.ErrorStyle.Render(message, err[0].Error()))
-	}
-	return errors.New(charm.ErrorStyle.Render(message))
+	return charm.RenderError(message, err...)
 }
 
 // PrintResource directly prints the given data to the output.
@@ -147,6 +148,58 @@ func (p *Printer) PrintResource(data string) {
 	fmt.Fprintln(out, data)
 }
 
+// Watch runs frame, the part of a list or get command that fetches and prints the resources, and
+// re-runs it every --interval when --watch is set. Anything frame prints through the printer is redrawn
+// in place. Work that doesn't change between refreshes, like creating the client, is done before.
+func (p *Printer) Watch(frame func() error) error {
+	if !common.Watch {
+		return frame()
+	}
+	if common.OutputFormat == "json" {
+		return UsageError("--watch can't be combined with json output")
+	}
+	if common.WatchInterval <= 0 {
+		return UsageError("--interval must be greater than zero")
+	}
+
+	out := p.resourceOut
+	defer p.SetOut(out)
+
+	return charm.Watch(common.WatchInterval, func() (string, error) {
+		var buf bytes.Buffer
+		p.SetOut(&buf)
+		err := frame()
+		return buf.String(), err
+	})
+}
+
+// AddQuietFlag adds -q/--quiet to a command whose output can be limited to resource IDs.
+func AddQuietFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVarP(&common.Quiet, "quiet", "q", false, "only print the IDs of created or listed resources, one per line")
+}
+
+// AddWatchFlags adds -w/--watch and --interval to a command that prints through Watch.
+func AddWatchFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVarP(&common.Watch, "watch", "w", false, "re-run the command every --interval, press ctrl+c to stop")
+	cmd.Flags().DurationVar(&common.WatchInterval, "interval", 5*time.Second, "how often --watch refreshes")
+}
+
+// PrintIDs prints one resource identifier per line, used for --quiet output.
+func (p *Printer) PrintIDs(ids ...string) {
+	for _, id := range ids {
+		p.PrintResource(id)
+	}
+}
+
+// PrintWarning prints a warning to stderr so it never mixes with resource output.
+func (p *Printer) PrintWarning(message string) {
+	var out io.Writer = os.Stderr
+	if p.errOut != nil {
+		out = p.errOut
+	}
+	fmt.Fprintln(out, charm.RenderWarning(message))
+}
+
 // generate random strings of a given length, for testing
 func GenerateRandomString(length int) string {
 	const charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"